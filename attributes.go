@@ -2,10 +2,12 @@ package html5tag
 
 import (
 	"encoding/gob"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html"
 	"io"
+	"net/url"
 	"reflect"
 	"regexp"
 	"sort"
@@ -13,8 +15,10 @@ import (
 	"strings"
 )
 
-// FalseValue is use by Set to set a boolean attribute to false. The Has() function will return true, but
-// the value will not appear in the attribute list when converted to a string.
+// FalseValue is used to turn off a boolean attribute. Set, Merge and Override remove an attribute that is given
+// FalseValue. If an attribute is put in the map with FalseValue directly, Has will return true, but the attribute
+// is treated as absent by everything that renders or reads the attributes as html, like String, WriteTo, ToMap,
+// Selector, JSXString and Diff, so it is never written.
 const FalseValue = "**GORADD-FALSE**"
 
 // Attributer is a general purpose interface for objects that return attributes based on information given.
@@ -37,10 +41,26 @@ func NewAttributes() Attributes {
 	return make(map[string]string)
 }
 
-// Copy returns a copy of the attributes.
+// Copy returns a copy of the attributes. The copy is exact, so attributes set to FalseValue are kept,
+// and like in the original, they are not rendered. See FalseValue.
 func (a Attributes) Copy() Attributes {
-	a2 := Attributes{}
-	return a2.Merge(a)
+	a2 := make(Attributes, len(a))
+	for k, v := range a {
+		a2[k] = v
+	}
+	return a2
+}
+
+// ToMap returns a copy of the attributes as a plain map. Attributes set to FalseValue are left out,
+// since they are not really set, and boolean attributes have an empty string value.
+func (a Attributes) ToMap() map[string]string {
+	m := make(map[string]string, len(a))
+	for k, v := range a {
+		if v != FalseValue {
+			m[k] = resolveValue(v)
+		}
+	}
+	return m
 }
 
 // Len returns the number of attributes.
@@ -60,6 +80,19 @@ func (a Attributes) Has(attr string) bool {
 	return ok
 }
 
+// IsBooleanTrue returns true if the named attribute is set with an empty value, which is how a boolean
+// attribute is turned on, and which renders as just the name of the attribute.
+func (a Attributes) IsBooleanTrue(name string) bool {
+	v, ok := a[name]
+	return ok && v == ""
+}
+
+// IsBooleanFalse returns true if the named attribute is set to FalseValue, which means it is present
+// in the attributes but will not be rendered.
+func (a Attributes) IsBooleanFalse(name string) bool {
+	return a[name] == FalseValue
+}
+
 // Get returns the named attribute.
 func (a Attributes) Get(attr string) string {
 	return a[attr]
@@ -77,9 +110,11 @@ func (a Attributes) Remove(attr string) {
 // on them. Returns err if the given attribute name or value is not valid.
 //
 // Use SetDataChanged when setting data attributes for additional validity checks.
+//
+// Namespace qualified names used by embedded SVG and MathML, like "xlink:href" and "xml:lang", are allowed,
+// but other names with a colon are not. See IsNamespacedAttribute.
 func (a Attributes) SetChanged(name string, v string) (changed bool, err error) {
-	if strings.Contains(name, " ") {
-		err = errors.New("attribute names cannot contain spaces")
+	if err = checkAttributeName(name); err != nil {
 		return
 	}
 
@@ -95,6 +130,11 @@ func (a Attributes) SetChanged(name string, v string) (changed bool, err error)
 			return
 		}
 
+		if styles.Len() == 0 { // an empty style attribute is the same as no style attribute
+			changed = a.RemoveAttribute("style")
+			return
+		}
+
 		oldStyles := a.StyleMap()
 
 		if !reflect.DeepEqual(oldStyles, styles) { // since maps are not ordered, we must use a special equality test. We can't just compare strings for equality here.
@@ -111,17 +151,127 @@ func (a Attributes) SetChanged(name string, v string) (changed bool, err error)
 		return
 	}
 	if strings.HasPrefix(name, "data-") {
-		return a.SetDataChanged(name[5:], v)
+		key := name[5:]
+		if strings.Contains(key, "-") {
+			// the name is already in kebab-case, as it would be when parsed from html
+			if !dataKebabMatcher.MatchString(key) {
+				err = fmt.Errorf("%s is not an acceptable data attribute name", name)
+				return
+			}
+			changed = a.set(name, v)
+			return
+		}
+		return a.SetDataChanged(key, v)
 	}
 	changed = a.set(name, v)
 	return
 }
 
-// set is a raw set and return true if changed
+// WouldChange returns true if calling SetChanged with the given name and value would change the attributes.
+// It uses the same comparison as SetChanged, including the special handling of class and style attributes,
+// but only looks at the current value of the attribute, without copying or changing the attributes, so it is cheap
+// and safe to call while other goroutines are reading them.
+// Returns false if SetChanged would return an error.
+func (a Attributes) WouldChange(name string, v string) bool {
+	if checkAttributeName(name) != nil {
+		return false
+	}
+	if v == FalseValue {
+		return a.Has(name)
+	}
+	switch {
+	case name == "style":
+		styles := NewStyle()
+		if _, err := styles.SetString(v); err != nil {
+			return false
+		}
+		if styles.Len() == 0 {
+			return a.Has("style")
+		}
+		return !reflect.DeepEqual(a.StyleMap(), styles)
+	case name == "id":
+		if v == "" {
+			return a.Has("id")
+		}
+		if strings.ContainsAny(v, " ") {
+			return false
+		}
+	case name == "class":
+		cur, has := a["class"]
+		switch {
+		case v == "":
+			return has
+		case strings.HasPrefix(v, "+ "):
+			if has && (isLazyValue(cur) || isLazyValue(v[2:])) {
+				return v[2:] != "" && cur != v[2:]
+			}
+			return v[2:] != "" && (!has || MergeWords(cur, v[2:]) != cur)
+		case strings.HasPrefix(v, "- "):
+			return has && RemoveWords(cur, v[2:]) != cur
+		}
+	case strings.HasPrefix(name, "data-"):
+		key := name[5:]
+		if strings.Contains(key, "-") {
+			if !dataKebabMatcher.MatchString(key) {
+				return false
+			}
+		} else {
+			if strings.ContainsAny(key, " !$") {
+				return false
+			}
+			suffix, err := ToDataAttr(key)
+			if err != nil {
+				return false
+			}
+			name = "data-" + suffix
+		}
+	}
+	old, ok := a[name]
+	return !ok || old != v
+}
+
+// checkAttributeName returns an error if name cannot be written as the name of an html attribute, because it is empty,
+// or has white space, quotes, or one of the characters that end an attribute name, like "=" or ">".
+// A name with a colon must be a namespace qualified name. See IsNamespacedAttribute.
+func checkAttributeName(name string) error {
+	if strings.ContainsAny(name, " \t\n\r\f") {
+		return errors.New("attribute names cannot contain spaces")
+	}
+	if name == "" {
+		return errors.New("attribute names cannot be empty")
+	}
+	for _, c := range name {
+		if c < 0x20 || c == 0x7f || c == '"' || c == '\'' || c == '>' || c == '/' || c == '=' {
+			return fmt.Errorf("%q is not a valid attribute name", name)
+		}
+	}
+	if strings.Contains(name, ":") && !IsNamespacedAttribute(name) {
+		return fmt.Errorf("%q is not a valid attribute name. Only the xlink, xml and xmlns namespaces are allowed", name)
+	}
+	return nil
+}
+
+// namespacePrefixes are the namespace prefixes that html allows in attribute names of foreign elements like svg.
+var namespacePrefixes = []string{"xlink:", "xml:", "xmlns:"}
+
+// IsNamespacedAttribute returns true if name is a namespace qualified attribute name that html allows on
+// foreign elements like svg and math, for example "xlink:href", "xml:lang" or "xmlns:xlink".
+func IsNamespacedAttribute(name string) bool {
+	for _, p := range namespacePrefixes {
+		if len(name) > len(p) && strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// set is a raw set and return true if changed. The attributes are not written to if nothing changes.
 func (a Attributes) set(k string, v string) bool {
-	oldVal, existed := a[k]
+	if oldVal, existed := a[k]; existed && oldVal == v {
+		return false
+	}
 	a[k] = v
-	return !existed || oldVal != v
+	return true
 }
 
 // Set sets a particular attribute and returns Attributes so that it can be chained.
@@ -129,8 +279,8 @@ func (a Attributes) set(k string, v string) bool {
 // It looks for special attributes like "class", "style" and "data" to do some error checking
 // on them. Use SetData to set data attributes.
 //
-// Pass v an empty string to create a boolean TRUE attribute, or to FalseValue to set the attribute
-// such that you know it has been set, but will not print in the final html string.
+// Pass v an empty string to create a boolean TRUE attribute, or FalseValue to remove the attribute,
+// the same way Merge and Override do. See FalseValue.
 func (a Attributes) Set(name string, v string) Attributes {
 	_, err := a.SetChanged(name, v)
 	if err != nil {
@@ -206,21 +356,35 @@ func (a Attributes) String() string {
 }
 
 // SortedString returns the attributes escaped and encoded, ready to be placed in an HTML tag
-// For consistency, it will use attrSpecialSort to order the keys.
+// For consistency, it will use attrSpecialSort to order the keys. It is the same as String.
 func (a Attributes) SortedString() string {
-	if a == nil {
-		return ""
-	}
-	b := strings.Builder{}
-	_, err := a.WriteSortedTo(&b)
-	if err != nil {
-		panic(err)
-	}
-	return b.String()
+	return a.String()
+}
+
+// explicitEmptyAttributes are the attributes that are written as name="" when their value is empty.
+var explicitEmptyAttributes = map[string]bool{
+	"alt":         true,
+	"placeholder": true,
+	"title":       true,
+	"value":       true,
+}
+
+// IsExplicitEmptyAttribute returns true if the named attribute is written as name="" when its value is empty,
+// rather than as a bare boolean attribute. For these attributes, an empty value has a meaning
+// that is worth making clear, like an empty value in an input or an empty alt for a decorative image.
+// These are alt, placeholder, title and value.
+func IsExplicitEmptyAttribute(name string) bool {
+	return explicitEmptyAttributes[name]
+}
+
+// isBareAttribute returns true if the attribute should be written as only its name.
+func isBareAttribute(k, v string) bool {
+	return v == "" && !explicitEmptyAttributes[k]
 }
 
 func writeKV(w io.Writer, k, v string) (n int, err error) {
-	if v == "" {
+	v = resolveValue(v)
+	if isBareAttribute(k, v) {
 		if n, err = writeString(w, k, n); err != nil {
 			return
 		}
@@ -242,63 +406,288 @@ func writeKV(w io.Writer, k, v string) (n int, err error) {
 	return
 }
 
-// WriteSortedTo writes the attributes escaped, encoded and with sorted keys.
-func (a Attributes) WriteSortedTo(w io.Writer) (n int64, err error) {
-	if a == nil {
-		return
+// RenderedSizeDelta returns the number of bytes that rendering newAttr takes, minus the number of bytes that
+// rendering oldAttr takes, as written by WriteTo. The sizes are calculated without rendering the attributes.
+func RenderedSizeDelta(oldAttr, newAttr Attributes) int {
+	return newAttr.renderedLen() - oldAttr.renderedLen()
+}
+
+// renderedLen returns the number of bytes that WriteTo would write.
+func (a Attributes) renderedLen() int {
+	var n int
+	for k, v := range a {
+		if v == FalseValue {
+			continue
+		}
+		if n > 0 {
+			n++ // the space between the attributes
+		}
+		n += kvLen(k, v)
 	}
-	var n1 int
+	return n
+}
+
+// kvLen returns the number of bytes that writeKV would write.
+func kvLen(k, v string) int {
+	if v = resolveValue(v); !isBareAttribute(k, v) {
+		return len(k) + 3 + escapedLen(v)
+	}
+	return len(k)
+}
+
+// isRendered returns true if at least one attribute would be written, which is not the case if the attributes
+// are empty or all set to FalseValue.
+func (a Attributes) isRendered() bool {
+	for _, v := range a {
+		if v != FalseValue {
+			return true
+		}
+	}
+	return false
+}
+
+// renderedKeys returns the keys of the attributes that are written, in the order WriteTo writes them.
+// Attributes set to FalseValue are left out.
+func (a Attributes) renderedKeys() []string {
+	keys := a.sortedKeys()
+	i := 0
+	for _, k := range keys {
+		if a[k] != FalseValue {
+			keys[i] = k
+			i++
+		}
+	}
+	return keys[:i]
+}
+
+// escapedLen returns the length of s after escaping it with html.EscapeString.
+func escapedLen(s string) int {
+	n := len(s)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '<', '>':
+			n += 3
+		case '&', '\'', '"':
+			n += 4
+		}
+	}
+	return n
+}
+
+// mapEntryOverhead and mapOverhead are rough estimates of the memory a map of strings uses beyond the
+// bytes of its keys and values. Each entry holds two string headers, plus space for the hash table.
+const (
+	mapEntryOverhead = 40
+	mapOverhead      = 48
+)
+
+// ApproxSize returns the approximate number of bytes of memory the attributes use, counting the bytes in the keys
+// and values, and an estimate of the overhead of the map. Use it to find tags with large attributes, like big data
+// attributes or inline styles.
+func (a Attributes) ApproxSize() int {
+	return approxMapSize(a)
+}
+
+func approxMapSize(m map[string]string) int {
+	if m == nil {
+		return 0
+	}
+	n := mapOverhead
+	for k, v := range m {
+		n += len(k) + len(v) + mapEntryOverhead
+	}
+	return n
+}
 
-	sk := a.sortedKeys()
-	lastKey := len(sk) - 1
-	for i, k := range sk {
+// DebugString returns the attributes in a canonical form, so that attributes that mean the same thing always
+// give the same string. The keys are sorted alphabetically, the classes are sorted with duplicates removed,
+// and the style properties are sorted.
+//
+// It is meant for debugging and for comparing attributes in tests, and not for html output,
+// since the order of classes can matter to css.
+func (a Attributes) DebugString() string {
+	keys := make([]string, 0, len(a))
+	for k, v := range a {
+		if v != FalseValue {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	b := strings.Builder{}
+	for i, k := range keys {
 		v := a[k]
-		n1, err = writeKV(w, k, v)
-		n += int64(n1)
-		if err != nil {
-			return
+		switch k {
+		case "class":
+			classes := strings.Fields(v)
+			sort.Strings(classes)
+			v = strings.Join(classes, " ")
+			v = MergeWords("", v)
+		case "style":
+			s := NewStyle()
+			if _, err := s.SetString(v); err == nil {
+				v = s.String()
+			}
 		}
-		if i < lastKey {
-			n1, err = io.WriteString(w, " ")
-			n += int64(n1)
-			if err != nil {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		_, _ = writeKV(&b, k, v)
+	}
+	return b.String()
+}
+
+// AppendBytes appends the attributes escaped, encoded and with sorted keys to dst and returns the extended buffer.
+// The output is the same as SortedString.
+func (a Attributes) AppendBytes(dst []byte) []byte {
+	for i, k := range a.renderedKeys() {
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, k...)
+		if v := resolveValue(a[k]); !isBareAttribute(k, v) {
+			dst = append(dst, '=', '"')
+			dst = appendEscaped(dst, v)
+			dst = append(dst, '"')
+		}
+	}
+	return dst
+}
+
+// appendEscaped appends s to dst, escaping it the same way html.EscapeString does.
+func appendEscaped(dst []byte, s string) []byte {
+	last := 0
+	for i := 0; i < len(s); i++ {
+		var esc string
+		switch s[i] {
+		case '<':
+			esc = "&lt;"
+		case '>':
+			esc = "&gt;"
+		case '&':
+			esc = "&amp;"
+		case '\'':
+			esc = "&#39;"
+		case '"':
+			esc = "&#34;"
+		default:
+			continue
+		}
+		dst = append(dst, s[last:i]...)
+		dst = append(dst, esc...)
+		last = i + 1
+	}
+	return append(dst, s[last:]...)
+}
+
+// WriteToQuoted writes the attributes escaped, encoded and with sorted keys, using the given quote character,
+// which must be either a single or a double quote, to surround the values. Only the chosen quote character
+// is escaped in values, along with &, < and >.
+func (a Attributes) WriteToQuoted(w io.Writer, quote byte) (n int64, err error) {
+	var replacer *strings.Replacer
+	switch quote {
+	case '"':
+		replacer = doubleQuoteEscaper
+	case '\'':
+		replacer = singleQuoteEscaper
+	default:
+		err = fmt.Errorf("%q is not a valid quote character", quote)
+		return
+	}
+	var n1 int
+	for i, k := range a.renderedKeys() {
+		if i > 0 {
+			if n1, err = io.WriteString(w, " "); err != nil {
+				n += int64(n1)
 				return
 			}
+			n += int64(n1)
+		}
+		s := k
+		if v := resolveValue(a[k]); !isBareAttribute(k, v) {
+			s = k + "=" + string(quote) + replacer.Replace(v) + string(quote)
+		}
+		n1, err = io.WriteString(w, s)
+		n += int64(n1)
+		if err != nil {
+			return
 		}
 	}
 	return
 }
 
-// WriteTo writes the attributes escaped and encoded as fast as possible.
-func (a Attributes) WriteTo(w io.Writer) (n int64, err error) {
+var doubleQuoteEscaper = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&#34;")
+var singleQuoteEscaper = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `'`, "&#39;")
+
+// WriteSortedTo writes the attributes escaped, encoded and with sorted keys.
+// Since WriteTo also sorts the keys, it is the same as WriteTo.
+func (a Attributes) WriteSortedTo(w io.Writer) (n int64, err error) {
+	return a.WriteTo(w)
+}
+
+// WriteOrdered writes the attributes escaped and encoded, with the keys named in order written first, in the order
+// given, followed by the remaining keys in alphabetical order. Keys in order that are not in the attributes are skipped.
+//
+// Use this to enforce a house style for attribute ordering.
+func (a Attributes) WriteOrdered(w io.Writer, order []string) (n int64, err error) {
 	if a == nil {
 		return
 	}
-	var n1 int
-	i := 1
-	length := len(a)
+	keys := make([]string, 0, len(a))
+	used := make(map[string]bool, len(order))
+	for _, k := range order {
+		if v, ok := a[k]; ok && v != FalseValue && !used[k] {
+			keys = append(keys, k)
+			used[k] = true
+		}
+	}
+	rest := make([]string, 0, len(a)-len(keys))
 	for k, v := range a {
+		if !used[k] && v != FalseValue {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return a.writeKeysTo(w, append(keys, rest...))
+}
+
+// writeKeysTo writes the given keys and their values, separated by spaces.
+func (a Attributes) writeKeysTo(w io.Writer, keys []string) (n int64, err error) {
+	var n1 int
+
+	lastKey := len(keys) - 1
+	for i, k := range keys {
+		v := a[k]
 		n1, err = writeKV(w, k, v)
 		n += int64(n1)
 		if err != nil {
 			return
 		}
-		if i < length {
+		if i < lastKey {
 			n1, err = io.WriteString(w, " ")
 			n += int64(n1)
 			if err != nil {
 				return
 			}
-
 		}
-		i++
 	}
 	return
 }
 
+// WriteTo writes the attributes escaped and encoded.
+//
+// Keys are written in the same order that Range visits them, so the output is repeatable, and matches
+// WriteSortedTo and SortedString.
+func (a Attributes) WriteTo(w io.Writer) (n int64, err error) {
+	if a == nil {
+		return
+	}
+	return a.writeKeysTo(w, a.renderedKeys())
+}
+
 // Range will call f for each item in the attributes.
 //
-// Keys will be ranged over such that repeating the range will produce the same ordering of keys.
+// Keys will be ranged over such that repeating the range will produce the same ordering of keys,
+// which is also the order that WriteTo and String write them in.
 // Return true from the range function to continue iterating, or false to stop.
 func (a Attributes) Range(f func(key string, value string) bool) {
 	if a == nil {
@@ -312,12 +701,16 @@ func (a Attributes) Range(f func(key string, value string) bool) {
 }
 
 // Override will replace attributes with the attributes in overrides.
-// Conflicts are won by the given overrides.
+// Conflicts are won by the given overrides. An attribute set to FalseValue in overrides is removed.
 func (a Attributes) Override(overrides Attributes) Attributes {
 	if overrides == nil {
 		return a
 	}
 	for k, v := range overrides {
+		if v == FalseValue {
+			delete(a, k)
+			continue
+		}
 		a[k] = v
 	}
 	return a
@@ -327,6 +720,8 @@ func (a Attributes) Override(overrides Attributes) Attributes {
 // However, styles are merged, so that if both the passed in map and the current map have a styles attribute, the
 // actual style properties will get merged together. Style conflicts are won by the passed in map.
 // The class attribute will merge so that the final classes will be a union of the two.
+// An attribute set to FalseValue in the passed in map is removed, so that a boolean attribute can be turned off.
+// A value set by SetAttributeFunc is never merged with another value, and is replaced like other attributes.
 //
 // See Override for a merge that does not merge the styles or classes.
 func (a Attributes) Merge(aIn Attributes) Attributes {
@@ -334,12 +729,14 @@ func (a Attributes) Merge(aIn Attributes) Attributes {
 		return a
 	}
 	for k, v := range aIn {
-		if k == "style" {
-			if v2, ok := a[k]; ok {
+		if v == FalseValue {
+			delete(a, k)
+			continue
+		}
+		if v2, ok := a[k]; ok && !isLazyValue(v) && !isLazyValue(v2) {
+			if k == "style" {
 				v = MergeStyleStrings(v2, v)
-			}
-		} else if k == "class" {
-			if v2, ok := a[k]; ok {
+			} else if k == "class" {
 				v = MergeWords(v2, v)
 			}
 		}
@@ -348,35 +745,201 @@ func (a Attributes) Merge(aIn Attributes) Attributes {
 	return a
 }
 
+// Intersect returns new Attributes containing only the attributes that are in both a and other.
+//
+// The class attribute will contain the classes found in both, and the style attribute will contain the
+// style properties that have the same value in both. Other attributes must have the same value in both.
+func (a Attributes) Intersect(other Attributes) Attributes {
+	ret := NewAttributes()
+	for k, v := range a {
+		v2, ok := other[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "class":
+			var classes []string
+			for _, c := range strings.Fields(v) {
+				if HasWord(v2, c) {
+					classes = append(classes, c)
+				}
+			}
+			if classes != nil {
+				ret[k] = strings.Join(classes, " ")
+			}
+		case "style":
+			s := a.StyleMap()
+			s2 := other.StyleMap()
+			for p, pv := range s {
+				if pv2, ok2 := s2[p]; !ok2 || pv2 != pv {
+					delete(s, p)
+				}
+			}
+			if s.Len() > 0 {
+				ret[k] = s.String()
+			}
+		default:
+			if v == v2 {
+				ret[k] = v
+			}
+		}
+	}
+	return ret
+}
+
+// Union returns new Attributes containing the attributes in either a or other. Neither a nor other is changed.
+//
+// Classes and styles are combined, and conflicts are won by other, as in Merge.
+func (a Attributes) Union(other Attributes) Attributes {
+	return a.Copy().Merge(other)
+}
+
+// MergeAll returns new Attributes that are the result of merging each of the given sets, from left to right,
+// using the same rules as Merge. None of the given sets are changed. Nil sets are skipped.
+func MergeAll(sets ...Attributes) Attributes {
+	ret := NewAttributes()
+	for _, s := range sets {
+		ret.Merge(s)
+	}
+	return ret
+}
+
+// MergeAllTracked is like MergeAll, but also returns, for each attribute in the result, the name of the source
+// that last changed it, which is the source whose value won. Use it to find out where an attribute of a
+// composed component came from.
+//
+// Since a map has no order, the sources are merged in the order of their sorted names, so name them in a way
+// that sorts in the order they should be applied, like "1-base", "2-theme" and "3-instance".
+// Class and style attributes are combined as in Merge, and are credited to the last source that had them.
+func MergeAllTracked(sources map[string]Attributes) (Attributes, map[string]string) {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ret := NewAttributes()
+	from := make(map[string]string)
+	for _, name := range names {
+		ret.Merge(sources[name])
+		for k, v := range sources[name] {
+			if v == FalseValue {
+				delete(from, k)
+			} else {
+				from[k] = name
+			}
+		}
+	}
+	return ret, from
+}
+
+// Partition splits the attributes into two new sets. matched contains the attributes whose names pred returns
+// true for, and rest contains the others. The attributes are not changed.
+//
+// For example, to separate the data attributes from the others:
+//
+//	data, rest := a.Partition(func(name string) bool { return strings.HasPrefix(name, "data-") })
+func (a Attributes) Partition(pred func(name string) bool) (matched Attributes, rest Attributes) {
+	matched = NewAttributes()
+	rest = NewAttributes()
+	for k, v := range a {
+		if pred(k) {
+			matched[k] = v
+		} else {
+			rest[k] = v
+		}
+	}
+	return
+}
+
+// Normalize lowercases the names of all the attributes, since html attribute names are case-insensitive.
+// If two names differ only in case, their values are combined. Classes and styles are merged together, and for
+// other attributes, the value of the name that was already lowercase wins.
+// Returns true if something changed.
+func (a Attributes) Normalize() (changed bool) {
+	var mixed []string
+	for k := range a {
+		if k != strings.ToLower(k) {
+			mixed = append(mixed, k)
+		}
+	}
+	sort.Strings(mixed) // for consistent results when more than two names collide
+	for _, k := range mixed {
+		v := a[k]
+		delete(a, k)
+		lk := strings.ToLower(k)
+		if v2, ok := a[lk]; ok {
+			switch lk {
+			case "class":
+				v = MergeWords(v2, v)
+			case "style":
+				v = MergeStyleStrings(v, v2)
+			default:
+				v = v2
+			}
+		}
+		a[lk] = v
+		changed = true
+	}
+	return
+}
+
 // OverrideString merges an attribute string into the attributes. Conflicts are won by the string.
 //
 // It takes an attribute string of the form
 //
 //	a="b" c="d"
+//
+// It panics if the string has an attribute name that is not valid. Use OverrideStringChanged to get an error instead.
 func (a Attributes) OverrideString(s string) Attributes {
-	if s == "" {
-		return a
+	if _, err := a.OverrideStringChanged(s); err != nil {
+		panic(err)
 	}
-	a2 := getAttributesFromTemplate(s)
-	a.Override(a2)
 	return a
 }
 
+// OverrideStringChanged is like OverrideString, but returns whether something changed, and returns an error
+// rather than panicking if the string cannot be parsed. The attributes are not changed on error.
+func (a Attributes) OverrideStringChanged(s string) (changed bool, err error) {
+	return a.mergeString(s, Attributes.Override)
+}
+
 // MergeString merges an attribute string into the attributes.
 // Conflicts are won by the string, but styles and classes merge.
 //
 // It takes an attribute string of the form
 //
 //	a="b" c="d"
+//
+// It panics if the string has an attribute name that is not valid. Use MergeStringChanged to get an error instead.
 func (a Attributes) MergeString(s string) Attributes {
-	if s == "" {
-		return a
+	if _, err := a.MergeStringChanged(s); err != nil {
+		panic(err)
 	}
-	a2 := getAttributesFromTemplate(s)
-	a.Merge(a2)
 	return a
 }
 
+// MergeStringChanged is like MergeString, but returns whether something changed, and returns an error
+// rather than panicking if the string cannot be parsed. The attributes are not changed on error.
+func (a Attributes) MergeStringChanged(s string) (changed bool, err error) {
+	return a.mergeString(s, Attributes.Merge)
+}
+
+// mergeString parses s and combines it with the attributes using merge.
+func (a Attributes) mergeString(s string, merge func(Attributes, Attributes) Attributes) (changed bool, err error) {
+	if s == "" {
+		return
+	}
+	a2, err := ParseAttributes(s)
+	if err != nil || a2 == nil {
+		return
+	}
+	before := a.Copy()
+	merge(a, a2)
+	changed = !reflect.DeepEqual(before, a)
+	return
+}
+
 // SetIDChanged sets the id to the given value and returns true if something changed.
 // In other words, if you set the id to the same value that it currently is, it will return false.
 // It will return an error if you attempt to set the id to an illegal value.
@@ -412,6 +975,37 @@ func (a Attributes) ID() string {
 	return a.Get("id")
 }
 
+// langMatcher matches a well-formed BCP-47 language tag. The primary language subtag must be two or three
+// letters, and is followed by optional extended language, script, region, variant, extension and private use
+// subtags. A tag made only of private use subtags, like "x-klingon", is also accepted.
+var langMatcher = regexp.MustCompile(`(?i)^(?:[a-z]{2,3}(?:-[a-z]{3}){0,3}(?:-[a-z]{4})?(?:-(?:[a-z]{2}|[0-9]{3}))?` +
+	`(?:-(?:[a-z0-9]{5,8}|[0-9][a-z0-9]{3}))*(?:-[0-9a-wyz](?:-[a-z0-9]{2,8})+)*(?:-x(?:-[a-z0-9]{1,8})+)?` +
+	`|x(?:-[a-z0-9]{1,8})+)$`)
+
+// SetLang sets the lang attribute to the given BCP-47 language tag, like "en" or "en-US".
+//
+// The tag is checked to see that it is well-formed, but not that its subtags are registered. An error is
+// returned for malformed tags, like "en_US" or "english", and the attributes are not changed.
+// An empty tag is allowed, and tells the browser that the language is unknown.
+func (a Attributes) SetLang(tag string) (Attributes, error) {
+	if tag != "" && !langMatcher.MatchString(tag) {
+		return a, fmt.Errorf("%q is not a valid BCP-47 language tag", tag)
+	}
+	a.set("lang", tag)
+	return a, nil
+}
+
+// SetDir sets the dir attribute, which gives the direction of the text in the tag.
+// dir must be "ltr", "rtl" or "auto", otherwise an error is returned and the attributes are not changed.
+func (a Attributes) SetDir(dir string) (Attributes, error) {
+	switch dir {
+	case "ltr", "rtl", "auto":
+		a.set("dir", dir)
+		return a, nil
+	}
+	return a, fmt.Errorf("%q is not a valid dir value. Use ltr, rtl or auto", dir)
+}
+
 // SetClassChanged sets the class attribute to the value given.
 //
 // If you prefix the value with "+ " the given value will be appended to the end of the current class list.
@@ -476,6 +1070,40 @@ func (a Attributes) RemoveClassesWithPrefix(v string) bool {
 	return false
 }
 
+// RemapClasses replaces each class that is a key in mapping with the class or classes in its value, keeping the
+// order of the classes and removing duplicates. A class mapped to an empty string is removed. If no classes
+// are left, the class attribute is removed. Returns true if the class attribute changed.
+func (a Attributes) RemapClasses(mapping map[string]string) bool {
+	oldClass, ok := a["class"]
+	if !ok {
+		return false
+	}
+	var classes []string
+	found := make(map[string]bool)
+	for _, c := range strings.Fields(oldClass) {
+		newClasses := []string{c}
+		if m, ok := mapping[c]; ok {
+			newClasses = strings.Fields(m)
+		}
+		for _, c2 := range newClasses {
+			if !found[c2] {
+				found[c2] = true
+				classes = append(classes, c2)
+			}
+		}
+	}
+	if len(classes) == 0 {
+		delete(a, "class")
+		return true
+	}
+	newClass := strings.Join(classes, " ")
+	if newClass == oldClass {
+		return false
+	}
+	a.set("class", newClass)
+	return true
+}
+
 // HasClassWithPrefix returns true if the attribute has a class with the given prefix.
 func (a Attributes) HasClassWithPrefix(prefix string) bool {
 	if a.Has("class") {
@@ -488,13 +1116,17 @@ func (a Attributes) HasClassWithPrefix(prefix string) bool {
 // AddValuesChanged adds the given space separated values to the end of the values in the
 // given attribute, removing duplicates and returning true if the attribute was changed at all.
 // An example of a place to use this is the aria-labelledby attribute, which can take multiple
-// space-separated id numbers.
+// space-separated id numbers. A value set by SetAttributeFunc is replaced rather than added to.
 func (a Attributes) AddValuesChanged(attrKey string, values string) bool {
 	if values == "" {
 		return false // nothing to add
 	}
 	if a.Has(attrKey) {
 		attrValue := a.Get(attrKey)
+		if isLazyValue(attrValue) || isLazyValue(values) {
+			// a placeholder cannot be combined with other values, so it is replaced
+			return a.set(attrKey, values)
+		}
 		newValues := MergeWords(attrValue, values)
 		if newValues != attrValue {
 			a.set(attrKey, newValues)
@@ -528,6 +1160,13 @@ func (a Attributes) AddClass(v string) Attributes {
 	return a
 }
 
+// SetClassMap sets the class attribute to the keys in m whose value is true, replacing the current classes.
+// See ClassMap.
+func (a Attributes) SetClassMap(m map[string]bool) Attributes {
+	a.SetClassChanged(ClassMap(m))
+	return a
+}
+
 // Class returns the value of the class attribute.
 func (a Attributes) Class() string {
 	return a.Get("class")
@@ -629,6 +1268,119 @@ func (a Attributes) HasDataAttribute(key string) bool {
 	return a.Has(key)
 }
 
+// SetDataStrings sets the given data attribute to a JSON array of the given strings. Javascript can retrieve
+// the values with JSON.parse(element.dataset.name). The name must be in camelCase, as in SetData.
+func (a Attributes) SetDataStrings(name string, values []string) Attributes {
+	if values == nil {
+		values = []string{}
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		panic(err)
+	}
+	return a.SetData(name, string(b))
+}
+
+// DataStrings returns the strings previously stored in a data attribute with SetDataStrings.
+// The key should be in camelCase. Returns nil if the attribute does not exist or is not a JSON array of strings.
+func (a Attributes) DataStrings(name string) []string {
+	v := a.DataAttribute(name)
+	if v == "" {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(v), &values); err != nil {
+		return nil
+	}
+	return values
+}
+
+// MergeDataJSON merges other into the attributes like Merge does, except that if both contain the data attribute
+// named by dataKey, and both values are JSON objects, the objects are deep merged rather than having other's
+// value replace the current one. Conflicts within the objects are won by other. The key should be in camelCase.
+//
+// This is useful for layering configuration stored in a data attribute, like default settings and instance settings.
+// Returns an error, and leaves the attributes unchanged, if either data value is not valid JSON.
+func (a Attributes) MergeDataJSON(other Attributes, dataKey string) error {
+	suffix, err := ToDataAttr(dataKey)
+	if err != nil {
+		return err
+	}
+	name := "data-" + suffix
+	v1, ok1 := a[name]
+	v2, ok2 := other[name]
+	if !ok1 || !ok2 {
+		a.Merge(other)
+		return nil
+	}
+	j1, err := decodeJSONNumbers(v1)
+	if err != nil {
+		return err
+	}
+	j2, err := decodeJSONNumbers(v2)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(deepMergeJSON(j1, j2))
+	if err != nil {
+		return err
+	}
+	a.Merge(other)
+	a[name] = string(b)
+	return nil
+}
+
+// decodeJSONNumbers unmarshals the JSON in s, keeping numbers as json.Number so that they are written back exactly,
+// without losing the precision of large integers.
+func decodeJSONNumbers(s string) (v interface{}, err error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	if err = d.Decode(&v); err != nil {
+		return
+	}
+	if d.More() {
+		err = errors.New("unexpected data after the JSON value")
+	}
+	return
+}
+
+// deepMergeJSON merges unmarshalled JSON value v2 into v1. Objects are merged recursively,
+// and anything else in v2 replaces what is in v1.
+func deepMergeJSON(v1, v2 interface{}) interface{} {
+	m1, ok1 := v1.(map[string]interface{})
+	m2, ok2 := v2.(map[string]interface{})
+	if !ok1 || !ok2 {
+		return v2
+	}
+	for k, v := range m2 {
+		if cur, ok := m1[k]; ok {
+			m1[k] = deepMergeJSON(cur, v)
+		} else {
+			m1[k] = v
+		}
+	}
+	return m1
+}
+
+// DataQueryString returns the data-* attributes as a url encoded query string, suitable for a GET request.
+// The keys are converted to camelCase with DataKeyName, the same way javascript does for the dataset property,
+// and are sorted. A name that DataKeyName cannot convert, like data-x-y, is used as is, without the data- prefix.
+//
+// For example, data-user-id="5" data-tab="a b" becomes "tab=a+b&userId=5".
+func (a Attributes) DataQueryString() string {
+	v := url.Values{}
+	for k, val := range a {
+		if strings.HasPrefix(k, "data-") && val != FalseValue {
+			key, err := DataKeyName(k)
+			if err != nil {
+				key = k[5:]
+			}
+			v.Set(key, val)
+		}
+	}
+	return v.Encode()
+}
+
 // StyleString returns the css style string, or a blank string if there is none.
 func (a Attributes) StyleString() string {
 	return a.Get("style")
@@ -682,6 +1434,17 @@ func (a Attributes) SetStylesTo(s string) Attributes {
 	return a
 }
 
+// SetStyleMap replaces the current styles with the given styles. Styles that are not in s are removed.
+// If s is empty, the style attribute is removed.
+func (a Attributes) SetStyleMap(s Style) Attributes {
+	if len(s) == 0 {
+		delete(a, "style")
+		return a
+	}
+	a.set("style", s.String())
+	return a
+}
+
 // GetStyle gives you the value of a single style attribute value. If you want all the attributes as a style string, use
 // StyleString().
 func (a Attributes) GetStyle(name string) string {
@@ -715,6 +1478,206 @@ func (a Attributes) RemoveStyle(name string) (changed bool) {
 	return changed
 }
 
+// ConflictingStyles returns the style properties that are set in the style attribute and are also set by one
+// of the classes of the tag. classToProperty maps a class name to the css property that the class sets, like
+// "d-block" to "display". The result is sorted, and is nil if there are no conflicts.
+//
+// Use this to find places where an inline style and a utility class are fighting over the same property.
+func (a Attributes) ConflictingStyles(classToProperty map[string]string) []string {
+	styles := a.StyleMap()
+	if len(styles) == 0 {
+		return nil
+	}
+	found := make(map[string]bool)
+	var ret []string
+	for _, c := range strings.Fields(a.Class()) {
+		if p, ok := classToProperty[c]; ok && styles.Has(p) && !found[p] {
+			found[p] = true
+			ret = append(ret, p)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Selector returns a css selector that matches an element with the given tag and attributes, like
+// `div#myId.cls1.cls2[data-x="y"]`. The id and classes are added with the # and . syntax, and the other attributes,
+// except for style, are added as attribute selectors. Identifiers and values are escaped as needed.
+func (a Attributes) Selector(tag string) string {
+	b := strings.Builder{}
+	b.WriteString(CSSEscape(tag))
+	for _, k := range a.renderedKeys() {
+		v := resolveValue(a[k])
+		switch k {
+		case "id":
+			if v != "" {
+				b.WriteString("#" + CSSEscape(v))
+			}
+			continue
+		case "class":
+			for _, c := range strings.Fields(v) {
+				b.WriteString("." + CSSEscape(c))
+			}
+			continue
+		case "style":
+			continue
+		}
+		b.WriteString("[" + CSSEscape(k))
+		if v != "" {
+			b.WriteString(`="` + cssStringEscaper.Replace(v) + `"`)
+		}
+		b.WriteString("]")
+	}
+	return b.String()
+}
+
+// cssStringEscaper escapes the contents of a double-quoted css string.
+var cssStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\a `, "\r", `\d `, "\f", `\c `, "\x00", "\uFFFD")
+
+// CSSEscape escapes s so that it can be used as an identifier in a css selector, the same way that the
+// javascript CSS.escape function does.
+func CSSEscape(s string) string {
+	b := strings.Builder{}
+	for i, r := range s {
+		switch {
+		case r == 0:
+			b.WriteRune('\uFFFD')
+		case r < 0x20 || r == 0x7f,
+			i == 0 && r >= '0' && r <= '9',
+			i == 1 && r >= '0' && r <= '9' && s[0] == '-':
+			b.WriteString(`\` + strconv.FormatInt(int64(r), 16) + " ")
+		case i == 0 && r == '-' && len(s) == 1:
+			b.WriteString(`\-`)
+		case r >= 0x80 || r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+			b.WriteRune(r)
+		default:
+			b.WriteString(`\`)
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// idReferenceAttributes are the attributes whose values are a single id, or a space separated list of ids
+// if the value is true.
+var idReferenceAttributes = map[string]bool{
+	"for":                   true,
+	"form":                  false,
+	"list":                  false,
+	"popovertarget":         false,
+	"headers":               true,
+	"itemref":               true,
+	"aria-activedescendant": false,
+	"aria-controls":         true,
+	"aria-describedby":      true,
+	"aria-details":          false,
+	"aria-errormessage":     false,
+	"aria-flowto":           true,
+	"aria-labelledby":       true,
+	"aria-owns":             true,
+}
+
+// ReferencedIDs returns the ids that the attributes refer to, like the id in a "for" attribute, or the ids in an
+// "aria-labelledby" attribute. Use it to check that the ids referred to by a tag actually exist in a document.
+// The result is sorted and has no duplicates, and is nil if no ids are referenced.
+func (a Attributes) ReferencedIDs() []string {
+	found := make(map[string]bool)
+	var ids []string
+	for k, v := range a {
+		isList, ok := idReferenceAttributes[k]
+		if !ok || v == FalseValue {
+			continue
+		}
+		values := []string{strings.TrimSpace(v)}
+		if isList {
+			values = strings.Fields(v)
+		}
+		for _, id := range values {
+			if id != "" && !found[id] {
+				found[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// jsxAttributeNames maps html attribute names to the names React uses for them in JSX.
+var jsxAttributeNames = map[string]string{
+	"class":           "className",
+	"for":             "htmlFor",
+	"tabindex":        "tabIndex",
+	"readonly":        "readOnly",
+	"maxlength":       "maxLength",
+	"minlength":       "minLength",
+	"colspan":         "colSpan",
+	"rowspan":         "rowSpan",
+	"contenteditable": "contentEditable",
+	"crossorigin":     "crossOrigin",
+	"autocomplete":    "autoComplete",
+	"autofocus":       "autoFocus",
+	"autoplay":        "autoPlay",
+	"accesskey":       "accessKey",
+	"enctype":         "encType",
+	"formaction":      "formAction",
+	"novalidate":      "noValidate",
+	"spellcheck":      "spellCheck",
+	"srcset":          "srcSet",
+	"usemap":          "useMap",
+	"datetime":        "dateTime",
+	"inputmode":       "inputMode",
+	"http-equiv":      "httpEquiv",
+	"charset":         "charSet",
+}
+
+var jsIdentifierMatcher = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// JSXString returns the attributes the way they would be written in a React JSX tag. Attribute names are
+// converted to the names React uses, like className for class and htmlFor for for, and the style attribute is
+// written as an object, like style={{backgroundColor: "red"}}. Event handler attributes, data-* and aria-*
+// attributes are written as is.
+func (a Attributes) JSXString() string {
+	var items []string
+	for _, k := range a.renderedKeys() {
+		v := resolveValue(a[k])
+		if k == "style" {
+			items = append(items, "style={{"+jsxStyleObject(a.StyleMap())+"}}")
+			continue
+		}
+		name := k
+		if n, ok := jsxAttributeNames[k]; ok {
+			name = n
+		}
+		if isBareAttribute(k, v) {
+			items = append(items, name)
+		} else {
+			items = append(items, name+`="`+html.EscapeString(v)+`"`)
+		}
+	}
+	return strings.Join(items, " ")
+}
+
+// jsxStyleObject returns the contents of a javascript object literal for the given style.
+func jsxStyleObject(s Style) string {
+	props := s.JSProperties()
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := make([]string, 0, len(keys))
+	for _, k := range keys {
+		v, _ := json.Marshal(props[k])
+		if !jsIdentifierMatcher.MatchString(k) {
+			b, _ := json.Marshal(k)
+			k = string(b)
+		}
+		items = append(items, k+": "+string(v))
+	}
+	return strings.Join(items, ", ")
+}
+
 // SetDisabled sets the "disabled" attribute to the given value.
 func (a Attributes) SetDisabled(d bool) Attributes {
 	if d {
@@ -747,6 +1710,56 @@ func (a Attributes) IsDisplayed() bool {
 	return a.GetStyle("display") != "none"
 }
 
+// VisibilityMode determines which mechanism NormalizeVisibility treats as the source of truth
+// when the hidden attribute and the display style disagree.
+type VisibilityMode int
+
+const (
+	// HiddenWins makes the hidden attribute the source of truth. A display:none style is converted to
+	// the hidden attribute, and a display style that contradicts the hidden attribute is removed.
+	HiddenWins VisibilityMode = iota
+	// DisplayWins makes the display style the source of truth. The hidden attribute is converted to
+	// a display:none style, unless a display style is already set, in which case the hidden attribute is removed.
+	DisplayWins
+)
+
+// NormalizeVisibility reconciles the hidden attribute and the display style so that only one of them controls
+// whether the element is visible. mode determines which one wins when both are present.
+// Returns true if something changed.
+func (a Attributes) NormalizeVisibility(mode VisibilityMode) (changed bool) {
+	if a == nil {
+		return false
+	}
+	hidden := a.Has("hidden") && !a.IsBooleanFalse("hidden")
+	hasDisplay := a.HasStyle("display")
+	display := a.GetStyle("display")
+
+	switch mode {
+	case HiddenWins:
+		if hidden && hasDisplay {
+			changed = a.RemoveStyle("display")
+		} else if !hidden && display == "none" {
+			a.RemoveStyle("display")
+			a.set("hidden", "")
+			changed = true
+		}
+	case DisplayWins:
+		if hidden {
+			a.RemoveAttribute("hidden")
+			if !hasDisplay {
+				a.SetStyle("display", "none")
+			}
+			changed = true
+		}
+	default:
+		panic("unknown visibility mode")
+	}
+	if changed && a.StyleString() == "" {
+		a.RemoveAttribute("style")
+	}
+	return
+}
+
 // ValueString is a helper function to convert an interface type to a string that is appropriate for the value
 // in the Set function.
 func ValueString(i interface{}) string {
@@ -762,36 +1775,204 @@ func ValueString(i interface{}) string {
 		return v
 	case int:
 		return strconv.Itoa(v)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
 	}
 	return fmt.Sprint(i)
 }
 
-// getAttributesFromTemplate returns Attributes extracted from a string in the form
-// of name="value"
-func getAttributesFromTemplate(s string) Attributes {
-	pairs := templateMatcher.FindAllString(s, -1)
-	if len(pairs) == 0 {
-		return nil
+// AttributesFromStruct returns Attributes built from the exported fields of the given struct, or pointer to
+// a struct, that have an "html" tag. The tag gives the name of the attribute, and the field value is
+// converted using ValueString, so bool fields become boolean attributes. Adding the omitempty option
+// will skip the field if it has a zero value. A tag of "-" skips the field.
+//
+//	type Config struct {
+//		ID       string `html:"id"`
+//		Title    string `html:"title,omitempty"`
+//		Disabled bool   `html:"disabled"`
+//	}
+func AttributesFromStruct(v interface{}) (Attributes, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New("AttributesFromStruct requires a non-nil struct")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("AttributesFromStruct requires a struct, %s given", rv.Kind())
 	}
 	a := NewAttributes()
-	for _, pair := range pairs {
-		kv := strings.Split(pair, "=")
-		val := kv[1][1 : len(kv[1])-1] // remove quotes
-		a.Set(kv[0], val)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		tag, ok := f.Tag.Lookup("html")
+		if !ok || tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if name == "" {
+			return nil, fmt.Errorf("field %s has an empty html attribute name", f.Name)
+		}
+		fv := rv.Field(i)
+		omitEmpty := false
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				omitEmpty = true
+			}
+		}
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+		if _, err := a.SetChanged(name, ValueString(fv.Interface())); err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
 	}
-	return a
+	return a, nil
 }
 
-/*
-type AttributeCreator map[string]string
+// ParseAttributes returns the Attributes found in a string in the form of name="value", as they would
+// appear in an html tag. Values can be double quoted, single quoted, unquoted, or missing altogether
+// to indicate a boolean attribute. Values are html unescaped, and then set as if by SetChanged, so
+// class and id attributes get the same checks. Returns nil if there are no attributes in the string.
+//
+// Since html attribute names are not case-sensitive, data-* names are lowercased, and they are kept in the
+// kebab-case they are written in rather than converted from camelCase. Like a browser, css declarations in a
+// style attribute that cannot be parsed are skipped. An error is returned if a name cannot be an attribute name.
+func ParseAttributes(s string) (a Attributes, err error) {
+	err = scanAttributes(s, func(name, val string) error {
+		if a == nil {
+			a = NewAttributes()
+		}
+		return a.setParsed(name, val)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// scanAttributes calls f with the name and the html unescaped value of each attribute in s, which is in the
+// form of name="value", without checking the names or values. It stops and returns the error if f returns one.
+func scanAttributes(s string, f func(name, val string) error) error {
+	for i := 0; i < len(s); {
+		// skip whitespace, and a slash as in a self-closing tag, before the name
+		for i < len(s) && (isAttrSpace(s[i]) || s[i] == '/') {
+			i++
+		}
+		start := i
+		for i < len(s) && !isAttrSpace(s[i]) && s[i] != '=' && s[i] != '/' {
+			i++
+		}
+		if i == start {
+			if i < len(s) {
+				i++ // a stray '='
+			}
+			continue
+		}
+		name := s[start:i]
 
-	func (c AttributeCreator) Create() Attributes {
-		return Attributes(c)
+		// skip whitespace around the equal sign
+		j := i
+		for j < len(s) && isAttrSpace(s[j]) {
+			j++
+		}
+		var val string
+		if j < len(s) && s[j] == '=' {
+			j++
+			for j < len(s) && isAttrSpace(s[j]) {
+				j++
+			}
+			if j < len(s) && (s[j] == '"' || s[j] == '\'') {
+				q := s[j]
+				j++
+				start = j
+				for j < len(s) && s[j] != q {
+					j++
+				}
+				val = s[start:j]
+				if j < len(s) {
+					j++ // closing quote
+				}
+			} else {
+				start = j
+				for j < len(s) && !isAttrSpace(s[j]) {
+					j++
+				}
+				val = s[start:j]
+			}
+			i = j
+		}
+		if err := f(name, html.UnescapeString(val)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setParsed sets an attribute that was parsed from html.
+func (a Attributes) setParsed(name string, v string) error {
+	if len(name) > 5 && strings.EqualFold(name[:5], "data-") {
+		name = strings.ToLower(name)
+		if err := checkAttributeName(name); err != nil {
+			return err
+		}
+		a.set(name, v)
+		return nil
+	}
+	if name == "style" {
+		v = parseStyleLeniently(v).String()
+	}
+	_, err := a.SetChanged(name, v)
+	return err
+}
+
+// parseStyleLeniently returns the css declarations in css that are valid, skipping the others the way a
+// browser does.
+func parseStyleLeniently(css string) Style {
+	s := NewStyle()
+	pieces := []string{css}
+	if _, err := SplitDeclarations(css); err != nil {
+		pieces = strings.Split(css, ";")
 	}
-*/
-var templateMatcher *regexp.Regexp
+	for _, p := range pieces {
+		decls, err := SplitDeclarations(p)
+		if err != nil {
+			continue
+		}
+		for _, d := range decls {
+			_, _ = s.SetChanged(d.Property, d.Value)
+		}
+	}
+	return s
+}
+
+func isAttrSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+var dataKebabMatcher = regexp.MustCompile(`^[a-z0-9_]+(-[a-z0-9_]+)*$`)
 
 func init() {
 	gob.Register(Attributes{})
-	templateMatcher = regexp.MustCompile(`\w+=".*?"`)
 }
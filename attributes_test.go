@@ -2,7 +2,9 @@ package html5tag
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -311,6 +313,71 @@ func ExampleAttributes_SetID() {
 	// false
 }
 
+func ExampleAttributes_SetLang() {
+	a, err := Attributes{}.SetLang("en-US")
+	fmt.Println(a, err)
+	_, err = a.SetLang("en_US")
+	fmt.Println(err)
+	// Output: lang="en-US" <nil>
+	// "en_US" is not a valid BCP-47 language tag
+}
+
+func TestAttributes_SetLang(t *testing.T) {
+	tests := []struct {
+		tag   string
+		valid bool
+	}{
+		{"", true},
+		{"en", true},
+		{"fr-CA", true},
+		{"zh-Hant-TW", true},
+		{"es-419", true},
+		{"de-CH-1901", true},
+		{"sl-rozaj-biske", true},
+		{"en-US-u-ca-gregory", true},
+		{"en-x-private", true},
+		{"x-klingon", true},
+		{"en_US", false},
+		{"english", false},
+		{"e", false},
+		{"en-", false},
+		{"-en", false},
+		{"en--US", false},
+		{"en US", false},
+		{"en-toolongsubtag", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			a := Attributes{"lang": "fr"}
+			_, err := a.SetLang(tt.tag)
+			if (err == nil) != tt.valid {
+				t.Errorf("SetLang(%q) error = %v, want valid %v", tt.tag, err, tt.valid)
+			}
+			if tt.valid && a.Get("lang") != tt.tag {
+				t.Errorf("SetLang(%q) set lang to %q", tt.tag, a.Get("lang"))
+			}
+			if !tt.valid && a.Get("lang") != "fr" {
+				t.Errorf("SetLang(%q) changed lang to %q", tt.tag, a.Get("lang"))
+			}
+		})
+	}
+}
+
+func TestAttributes_SetDir(t *testing.T) {
+	for _, dir := range []string{"ltr", "rtl", "auto"} {
+		a, err := Attributes{}.SetDir(dir)
+		if err != nil || a.Get("dir") != dir {
+			t.Errorf("SetDir(%q) = %v, %v", dir, a, err)
+		}
+	}
+	for _, dir := range []string{"", "LTR", "left"} {
+		a, err := Attributes{}.SetDir(dir)
+		if err == nil || a.Has("dir") {
+			t.Errorf("SetDir(%q) should fail, got %v", dir, a)
+		}
+	}
+}
+
 func ExampleAttributes_Override() {
 	a := NewAttributes().SetClass("this").SetStyle("height", "4em")
 	b := NewAttributes().Set("class", "that").SetStyle("width", "6")
@@ -430,6 +497,16 @@ func ExampleAttributes_SetStylesTo() {
 	// Output: style="color:red"
 }
 
+func ExampleAttributes_SetStyleMap() {
+	a := Attributes{"style": "color:blue;width:2px"}
+	a.SetStyleMap(Style{"height": "4px"})
+	fmt.Println(a.String())
+	a.SetStyleMap(nil)
+	fmt.Println(a.Has("style"))
+	// Output: style="height:4px"
+	// false
+}
+
 func ExampleAttributes_SetDisabled() {
 	a := Attributes{"style": "color:blue"}
 	a.SetDisabled(true)
@@ -591,3 +668,843 @@ func BenchmarkSortedKeys(b *testing.B) {
 		a.sortedKeys()
 	}
 }
+
+func ExampleAttributesFromStruct() {
+	type config struct {
+		ID       string `html:"id"`
+		Title    string `html:"title,omitempty"`
+		Size     int    `html:"size"`
+		Disabled bool   `html:"disabled"`
+		Hidden   bool   `html:"hidden"`
+		Other    string
+	}
+	a, _ := AttributesFromStruct(config{ID: "a", Size: 4, Disabled: true, Other: "b"})
+	fmt.Println(a.SortedString())
+	// Output: id="a" disabled size="4"
+}
+
+func TestAttributesFromStruct(t *testing.T) {
+	type good struct {
+		Title string `html:"title,omitempty"`
+		Skip  string `html:"-"`
+	}
+	type badName struct {
+		A string `html:"bad name"`
+	}
+	type emptyName struct {
+		A string `html:",omitempty"`
+	}
+
+	a, err := AttributesFromStruct(&good{Title: "t", Skip: "s"})
+	if err != nil || a.String() != `title="t"` {
+		t.Errorf("pointer to struct failed: %v %v", a, err)
+	}
+	if _, err = AttributesFromStruct(badName{"a"}); err == nil {
+		t.Error("expected error on bad attribute name")
+	}
+	if _, err = AttributesFromStruct(emptyName{"a"}); err == nil {
+		t.Error("expected error on empty attribute name")
+	}
+	if _, err = AttributesFromStruct("a"); err == nil {
+		t.Error("expected error on non-struct")
+	}
+	if _, err = AttributesFromStruct((*good)(nil)); err == nil {
+		t.Error("expected error on nil pointer")
+	}
+}
+
+func ExampleAttributes_WriteOrdered() {
+	a := Attributes{"id": "a", "class": "b", "data-testid": "c", "title": "d", "alt": "e"}
+	b := strings.Builder{}
+	_, _ = a.WriteOrdered(&b, []string{"data-testid", "id", "missing"})
+	fmt.Println(b.String())
+	// Output: data-testid="c" id="a" alt="e" class="b" title="d"
+}
+
+func TestAttributes_NormalizeVisibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       Attributes
+		mode    VisibilityMode
+		changed bool
+		want    string
+	}{
+		{"hidden wins over block", Attributes{"hidden": "", "style": "color:red;display:block"}, HiddenWins, true, `style="color:red" hidden`},
+		{"hidden wins redundant", Attributes{"hidden": "", "style": "display:none"}, HiddenWins, true, `hidden`},
+		{"hidden wins from display", Attributes{"style": "display:none"}, HiddenWins, true, `hidden`},
+		{"hidden wins no conflict", Attributes{"style": "display:block"}, HiddenWins, false, `style="display:block"`},
+		{"display wins over hidden", Attributes{"hidden": "", "style": "display:block"}, DisplayWins, true, `style="display:block"`},
+		{"display wins redundant", Attributes{"hidden": "", "style": "display:none"}, DisplayWins, true, `style="display:none"`},
+		{"display wins from hidden", Attributes{"id": "a", "hidden": ""}, DisplayWins, true, `id="a" style="display:none"`},
+		{"display wins no conflict", Attributes{"style": "display:none"}, DisplayWins, false, `style="display:none"`},
+		{"false hidden is not hidden", Attributes{"hidden": FalseValue, "style": "display:block"}, HiddenWins, false, `style="display:block"`},
+		{"false hidden from display", Attributes{"hidden": FalseValue, "style": "display:none"}, HiddenWins, true, `hidden`},
+		{"display wins false hidden", Attributes{"id": "a", "hidden": FalseValue}, DisplayWins, false, `id="a"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if changed := tt.a.NormalizeVisibility(tt.mode); changed != tt.changed {
+				t.Errorf("NormalizeVisibility() changed = %v, want %v", changed, tt.changed)
+			}
+			if got := tt.a.SortedString(); got != tt.want {
+				t.Errorf("NormalizeVisibility() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleAttributes_SetClassMap() {
+	a := Attributes{"class": "old"}
+	a.SetClassMap(map[string]bool{"active": true, "disabled": false})
+	fmt.Println(a.String())
+	a.SetClassMap(map[string]bool{"active": false})
+	fmt.Println(a.Has("class"))
+	// Output: class="active"
+	// false
+}
+
+func TestMergeStringValues(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want Attributes
+	}{
+		{"entity and equals", `href="/search?q=1&amp;p=2"`, Attributes{"href": "/search?q=1&p=2"}},
+		{"single quoted", `title='say "hi"' id="a"`, Attributes{"title": `say "hi"`, "id": "a"}},
+		{"boolean", `disabled id="a"`, Attributes{"disabled": "", "id": "a"}},
+		{"unquoted", `id=a  class = "b c"`, Attributes{"id": "a", "class": "b c"}},
+		{"dashed name", `data-my-val="1" aria-label='x'`, Attributes{"data-my-val": "1", "aria-label": "x"}},
+		{"unterminated", `title="abc`, Attributes{"title": "abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAttributes().MergeString(tt.s)
+			if !reflect.DeepEqual(a, tt.want) {
+				t.Errorf("MergeString() = %v, want %v", a, tt.want)
+			}
+			a = NewAttributes().OverrideString(tt.s)
+			if !reflect.DeepEqual(a, tt.want) {
+				t.Errorf("OverrideString() = %v, want %v", a, tt.want)
+			}
+		})
+	}
+
+	a := NewAttributes().MergeString(`href="/search?q=1&amp;p=2"`)
+	if s := a.String(); s != `href="/search?q=1&amp;p=2"` {
+		t.Errorf("MergeString() round trip = %v", s)
+	}
+}
+
+func ExampleAttributes_AppendBytes() {
+	a := Attributes{"title": `<"Tom" & 'Jerry'>`, "id": "a", "disabled": ""}
+	b := []byte("<div ")
+	b = a.AppendBytes(b)
+	fmt.Println(string(b))
+	// Output: <div id="a" disabled title="&lt;&#34;Tom&#34; &amp; &#39;Jerry&#39;&gt;"
+}
+
+func TestAttributes_AppendBytes(t *testing.T) {
+	a := Attributes{"title": `a<b>c&d"e'f`, "id": "a", "class": "b c", "disabled": "", "data-x": "y"}
+	if got := string(a.AppendBytes(nil)); got != a.SortedString() {
+		t.Errorf("AppendBytes() = %v, want %v", got, a.SortedString())
+	}
+}
+
+func BenchmarkAppendBytes(b *testing.B) {
+	a := Attributes{"a": "b", "id": "c", "width": "14", "d": "e"}
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		buf = a.AppendBytes(buf[:0])
+	}
+}
+
+func ExampleAttributes_SetDataStrings() {
+	a := NewAttributes().SetDataStrings("myList", []string{"a", `b"c`, "<d>"})
+	fmt.Println(a.String())
+	fmt.Println(a.DataStrings("myList"))
+	// Output: data-my-list="[&#34;a&#34;,&#34;b\&#34;c&#34;,&#34;\u003cd\u003e&#34;]"
+	// [a b"c <d>]
+}
+
+func TestAttributes_DataStrings(t *testing.T) {
+	a := NewAttributes().SetDataStrings("myList", []string{"x & y", "z'"})
+	a2 := NewAttributes().MergeString(a.String())
+	if got := a2.DataStrings("myList"); !reflect.DeepEqual(got, []string{"x & y", "z'"}) {
+		t.Errorf("DataStrings() after round trip = %v", got)
+	}
+	if got := NewAttributes().SetDataStrings("empty", nil).DataStrings("empty"); got == nil || len(got) != 0 {
+		t.Errorf("DataStrings() of empty list = %v", got)
+	}
+	if got := a.DataStrings("missing"); got != nil {
+		t.Errorf("DataStrings() of missing = %v", got)
+	}
+	if got := NewAttributes().SetData("bad", "x").DataStrings("bad"); got != nil {
+		t.Errorf("DataStrings() of non-json = %v", got)
+	}
+}
+
+func ExampleAttributes_Intersect() {
+	a := Attributes{"class": "row selected odd", "style": "color:red;width:4px", "title": "a", "lang": "en"}
+	b := Attributes{"class": "odd row", "style": "color:red;width:5px", "title": "b", "lang": "en"}
+	fmt.Println(a.Intersect(b).SortedString())
+	// Output: class="row odd" style="color:red" lang="en"
+}
+
+func ExampleAttributes_Union() {
+	a := Attributes{"class": "row selected", "style": "color:red", "title": "a"}
+	b := Attributes{"class": "odd row", "style": "width:5px", "title": "b"}
+	fmt.Println(a.Union(b).SortedString())
+	fmt.Println(a.SortedString())
+	// Output: class="row selected odd" style="color:red;width:5px" title="b"
+	// class="row selected" style="color:red" title="a"
+}
+
+func TestAttributes_IsBoolean(t *testing.T) {
+	a := Attributes{"disabled": "", "hidden": FalseValue, "title": "x"}
+	tests := []struct {
+		name      string
+		wantTrue  bool
+		wantFalse bool
+	}{
+		{"disabled", true, false},
+		{"hidden", false, true},
+		{"title", false, false},
+		{"missing", false, false},
+	}
+	for _, tt := range tests {
+		if got := a.IsBooleanTrue(tt.name); got != tt.wantTrue {
+			t.Errorf("IsBooleanTrue(%q) = %v, want %v", tt.name, got, tt.wantTrue)
+		}
+		if got := a.IsBooleanFalse(tt.name); got != tt.wantFalse {
+			t.Errorf("IsBooleanFalse(%q) = %v, want %v", tt.name, got, tt.wantFalse)
+		}
+	}
+	var n Attributes
+	if n.IsBooleanTrue("a") || n.IsBooleanFalse("a") {
+		t.Error("expected false on nil attributes")
+	}
+}
+
+func ExampleAttributes_ConflictingStyles() {
+	a := Attributes{"class": "d-block text-end mt-2", "style": "display:none;margin-top:4px;color:red"}
+	fmt.Println(a.ConflictingStyles(map[string]string{
+		"d-block":  "display",
+		"d-none":   "display",
+		"mt-2":     "margin-top",
+		"text-end": "text-align",
+	}))
+	// Output: [display margin-top]
+}
+
+func TestAttributes_ConflictingStyles(t *testing.T) {
+	m := map[string]string{"d-block": "display", "d-flex": "display"}
+	if c := (Attributes{"class": "d-block"}).ConflictingStyles(m); c != nil {
+		t.Errorf("expected no conflicts without a style, got %v", c)
+	}
+	if c := (Attributes{"style": "display:none"}).ConflictingStyles(m); c != nil {
+		t.Errorf("expected no conflicts without a class, got %v", c)
+	}
+	if c := (Attributes{"class": "d-block d-flex", "style": "display:none"}).ConflictingStyles(m); len(c) != 1 {
+		t.Errorf("expected one conflict, got %v", c)
+	}
+}
+
+func TestAttributes_MergeFalseValue(t *testing.T) {
+	a := Attributes{"disabled": "", "id": "a"}
+	a.Merge(Attributes{"disabled": FalseValue, "hidden": FalseValue})
+	if a.Has("disabled") || a.Has("hidden") {
+		t.Errorf("Merge() should remove FalseValue attributes, got %v", a)
+	}
+
+	a = Attributes{"disabled": "", "id": "a"}
+	a.Override(Attributes{"disabled": FalseValue})
+	if a.Has("disabled") || !a.Has("id") {
+		t.Errorf("Override() should remove FalseValue attributes, got %v", a)
+	}
+
+	m := MergeAll(Attributes{"disabled": ""}, Attributes{"disabled": FalseValue})
+	if m.Has("disabled") {
+		t.Errorf("MergeAll() should remove FalseValue attributes, got %v", m)
+	}
+
+	c := Attributes{"hidden": FalseValue}.Copy()
+	if !c.IsBooleanFalse("hidden") {
+		t.Error("Copy() should keep FalseValue attributes")
+	}
+	if c.String() != "" {
+		t.Errorf("a copied FalseValue attribute should not be rendered, got %s", c.String())
+	}
+
+	a = Attributes{"disabled": ""}
+	a.Set("disabled", FalseValue)
+	if a.Has("disabled") {
+		t.Errorf("Set() should remove FalseValue attributes, got %v", a)
+	}
+}
+
+func TestAttributes_FalseValueNotWritten(t *testing.T) {
+	a := Attributes{"id": "a", "hidden": FalseValue, "title": "t"}
+	want := `id="a" title="t"`
+	b := strings.Builder{}
+	_, _ = a.WriteToQuoted(&b, '"')
+	b2 := strings.Builder{}
+	_, _ = a.WriteOrdered(&b2, []string{"hidden", "title"})
+	outputs := map[string]string{
+		"String":        a.String(),
+		"SortedString":  a.SortedString(),
+		"AppendBytes":   string(a.AppendBytes(nil)),
+		"WriteToQuoted": b.String(),
+		"DebugString":   a.DebugString(),
+	}
+	for name, got := range outputs {
+		if got != want {
+			t.Errorf("%s() = %s, want %s", name, got, want)
+		}
+	}
+	if got := b2.String(); got != `title="t" id="a"` {
+		t.Errorf("WriteOrdered() = %s", got)
+	}
+	if a.renderedLen() != len(want) {
+		t.Errorf("renderedLen() = %d, want %d", a.renderedLen(), len(want))
+	}
+
+	off := Attributes{"hidden": FalseValue, "id": FalseValue}
+	if got := RenderVoidTag("input", off); got != "<input>" {
+		t.Errorf("RenderVoidTag() = %s", got)
+	}
+	if got := off.Selector("p"); got != "p" {
+		t.Errorf("Selector() = %s", got)
+	}
+	if got := off.JSXString(); got != "" {
+		t.Errorf("JSXString() = %s", got)
+	}
+	if off.renderedLen() != 0 || RenderedSizeDelta(nil, off) != 0 {
+		t.Errorf("renderedLen() = %d", off.renderedLen())
+	}
+	// Diff agrees with what is rendered
+	if d := off.Diff(nil); !d.IsEmpty() {
+		t.Errorf("Diff() = %v", d)
+	}
+}
+
+func ExampleAttributes_Selector() {
+	a := Attributes{"id": "myId", "class": "cls1 cls2", "data-x": "y", "style": "color:red", "disabled": ""}
+	fmt.Println(a.Selector("button"))
+	// Output: button#myId.cls1.cls2[data-x="y"][disabled]
+}
+
+func TestCSSEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"abc", "abc"},
+		{"a.b", `a\.b`},
+		{"1a", `\31 a`},
+		{"-1a", `-\31 a`},
+		{"-", `\-`},
+		{"--a", "--a"},
+		{"a b", `a\ b`},
+		{"x:y", `x\:y`},
+		{"é", "é"},
+		{"a\x00", "a\uFFFD"},
+		{"a\tb", `a\9 b`},
+	}
+	for _, tt := range tests {
+		if got := CSSEscape(tt.in); got != tt.want {
+			t.Errorf("CSSEscape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAttributes_Selector(t *testing.T) {
+	a := Attributes{"title": `say "hi"\`, "hidden": FalseValue}
+	if got := a.Selector("div"); got != `div[title="say \"hi\"\\"]` {
+		t.Errorf("Selector() = %s", got)
+	}
+	if got := Attributes(nil).Selector("p"); got != "p" {
+		t.Errorf("Selector() = %s", got)
+	}
+}
+
+func ExampleAttributes_ReferencedIDs() {
+	a := Attributes{"id": "btn", "aria-controls": "menu", "aria-labelledby": "lbl1 lbl2", "aria-describedby": "lbl1", "form": "f"}
+	fmt.Println(a.ReferencedIDs())
+	// Output: [f lbl1 lbl2 menu]
+}
+
+func TestAttributes_ReferencedIDs(t *testing.T) {
+	if ids := (Attributes{"id": "a", "title": "b"}).ReferencedIDs(); ids != nil {
+		t.Errorf("expected no ids, got %v", ids)
+	}
+	if ids := (Attributes{"for": " x ", "list": ""}).ReferencedIDs(); len(ids) != 1 || ids[0] != "x" {
+		t.Errorf("expected [x], got %v", ids)
+	}
+	if ids := (Attributes{"headers": "h1 h2", "aria-owns": FalseValue}).ReferencedIDs(); len(ids) != 2 {
+		t.Errorf("expected [h1 h2], got %v", ids)
+	}
+}
+
+func ExampleAttributes_Partition() {
+	a := Attributes{"id": "a", "data-x": "1", "data-y": "2", "class": "b"}
+	data, rest := a.Partition(func(name string) bool { return strings.HasPrefix(name, "data-") })
+	fmt.Println(data.SortedString())
+	fmt.Println(rest.SortedString())
+	fmt.Println(a.Len())
+	// Output: data-x="1" data-y="2"
+	// id="a" class="b"
+	// 4
+}
+
+func ExampleAttributes_JSXString() {
+	a := Attributes{
+		"class":    "btn",
+		"for":      "name",
+		"tabindex": "0",
+		"style":    "background-color:red;--main-color:blue",
+		"onclick":  "go()",
+		"disabled": "",
+		"data-x":   `a"b`,
+	}
+	fmt.Println(a.JSXString())
+	// Output: className="btn" style={{"--main-color": "blue", backgroundColor: "red"}} data-x="a&#34;b" disabled htmlFor="name" onclick="go()" tabIndex="0"
+}
+
+func ExampleRenderedSizeDelta() {
+	oldAttr := Attributes{"class": "a"}
+	newAttr := Attributes{"class": "a b", "disabled": ""}
+	fmt.Println(RenderedSizeDelta(oldAttr, newAttr), RenderedSizeDelta(newAttr, oldAttr))
+	// Output: 11 -11
+}
+
+func TestAttributes_renderedLen(t *testing.T) {
+	tests := []Attributes{
+		nil,
+		{},
+		{"a": ""},
+		{"value": ""},
+		{"title": `<a href="x">Tom & 'Jerry'</a>`},
+		{"id": "a", "class": "b c", "disabled": "", "data-x": "é"},
+	}
+	for _, a := range tests {
+		if got, want := a.renderedLen(), len(a.String()); got != want {
+			t.Errorf("renderedLen(%v) = %d, want %d", a, got, want)
+		}
+	}
+}
+
+func ExampleAttributes_RemapClasses() {
+	a := Attributes{"class": "btn btn-default pull-right btn-lg"}
+	a.RemapClasses(map[string]string{"btn-default": "btn-secondary", "pull-right": "float-end", "btn-lg": "btn-lg"})
+	fmt.Println(a.Class())
+	// Output: btn btn-secondary float-end btn-lg
+}
+
+func TestAttributes_RemapClasses(t *testing.T) {
+	tests := []struct {
+		class   string
+		mapping map[string]string
+		want    string
+		changed bool
+	}{
+		{"a b", map[string]string{"c": "d"}, "a b", false},
+		{"a b", map[string]string{"a": "b"}, "b", true},
+		{"a b c", map[string]string{"b": "x y"}, "a x y c", true},
+		{"a b", map[string]string{"a": ""}, "b", true},
+		{"a", map[string]string{"a": ""}, "", true},
+		{"a  b", nil, "a b", true},
+	}
+	for _, tt := range tests {
+		a := Attributes{"class": tt.class}
+		if changed := a.RemapClasses(tt.mapping); changed != tt.changed || a.Class() != tt.want {
+			t.Errorf("RemapClasses(%q) = %v, %q, want %v, %q", tt.class, changed, a.Class(), tt.changed, tt.want)
+		}
+	}
+	a := Attributes{}
+	if a.RemapClasses(map[string]string{"a": "b"}) || a.Has("class") {
+		t.Error("expected no change without a class attribute")
+	}
+}
+
+func TestApproxSize(t *testing.T) {
+	if n := Attributes(nil).ApproxSize(); n != 0 {
+		t.Errorf("ApproxSize() of nil = %d", n)
+	}
+	small := Attributes{"id": "a"}.ApproxSize()
+	big := Attributes{"id": "a", "data-x": strings.Repeat("x", 1000)}.ApproxSize()
+	if small <= 3 || big < small+1000 {
+		t.Errorf("ApproxSize() small = %d, big = %d", small, big)
+	}
+	if n := (Style{"color": "red"}).ApproxSize(); n != Attributes(map[string]string{"color": "red"}).ApproxSize() {
+		t.Errorf("Style.ApproxSize() = %d", n)
+	}
+}
+
+func ExampleAttributes_DebugString() {
+	a := Attributes{"id": "x", "class": "b a b", "style": "width: 4px; color: red"}
+	b := Attributes{"style": "color:red;width:4px", "class": "a b", "id": "x"}
+	fmt.Println(a.DebugString())
+	fmt.Println(a.DebugString() == b.DebugString())
+	// Output: class="a b" id="x" style="color:red;width:4px"
+	// true
+}
+
+func ExampleMergeAll() {
+	base := Attributes{"class": "btn", "type": "button"}
+	theme := Attributes{"class": "btn-dark", "style": "color:white"}
+	instance := Attributes{"id": "save", "style": "margin:2px"}
+	fmt.Println(MergeAll(base, theme, nil, instance).SortedString())
+	fmt.Println(base.SortedString())
+	// Output: id="save" class="btn btn-dark" style="color:white;margin:2px" type="button"
+	// class="btn" type="button"
+}
+
+func ExampleMergeAllTracked() {
+	a, from := MergeAllTracked(map[string]Attributes{
+		"1-base":     {"class": "btn", "type": "button", "title": "Save"},
+		"2-theme":    {"class": "btn-dark", "type": "submit"},
+		"3-instance": {"id": "save", "title": FalseValue},
+	})
+	fmt.Println(a.SortedString())
+	fmt.Println(from)
+	// Output: id="save" class="btn btn-dark" type="submit"
+	// map[class:2-theme id:3-instance type:2-theme]
+}
+
+func TestSetDataChangedDoubleDash(t *testing.T) {
+	a := NewAttributes()
+	if _, err := a.SetChanged("data--x", "y"); err == nil {
+		t.Errorf("expected an error, got %v", a)
+	}
+	if _, err := a.SetChanged("data-ab-", "y"); err == nil {
+		t.Errorf("expected an error, got %v", a)
+	}
+}
+
+func ExampleAttributes_Normalize() {
+	a := Attributes{"CLASS": "b", "class": "a", "Style": "color:red;width:4px", "style": "color:blue", "ID": "x", "Title": "t", "title": "u"}
+	a.Normalize()
+	fmt.Println(a.SortedString())
+	// Output: id="x" class="a b" style="color:blue;width:4px" title="u"
+}
+
+func TestAttributes_WouldChange(t *testing.T) {
+	a := Attributes{"id": "a", "class": "b c", "style": "width:4px;color:red", "title": "t"}
+	tests := []struct {
+		name, value string
+		want        bool
+	}{
+		{"id", "a", false},
+		{"id", "b", true},
+		{"title", "t", false},
+		{"title", FalseValue, true},
+		{"other", FalseValue, false},
+		{"style", "color:red; width:4", false},
+		{"style", "color:blue", true},
+		{"class", "+ b", false},
+		{"class", "- d", false},
+		{"class", "+ d", true},
+		{"bad name", "a", false},
+		{"id", "", true},
+		{"id", "a b", false},
+		{"class", "", true},
+		{"class", "c b", true},
+		{"class", "- b", true},
+		{"class", "+ ", false},
+		{"style", "", true},
+		{"style", "color", false},
+		{"data-my-value", "1", true},
+		{"data-myValue", "1", true},
+		{"data-my--value", "1", false},
+		{"new", "", true},
+	}
+	for _, tt := range tests {
+		if got := a.WouldChange(tt.name, tt.value); got != tt.want {
+			t.Errorf("WouldChange(%q, %q) = %v, want %v", tt.name, tt.value, got, tt.want)
+		}
+	}
+	if a.Len() != 4 || a.Class() != "b c" || a.Get("id") != "a" {
+		t.Errorf("WouldChange() changed the attributes: %v", a)
+	}
+
+	// WouldChange must agree with SetChanged
+	for _, tt := range tests {
+		a2 := a.Copy()
+		changed, _ := a2.SetChanged(tt.name, tt.value)
+		if got := a.WouldChange(tt.name, tt.value); got != changed {
+			t.Errorf("WouldChange(%q, %q) = %v, but SetChanged returned %v", tt.name, tt.value, got, changed)
+		}
+	}
+}
+
+func ExampleIsNamespacedAttribute() {
+	fmt.Println(IsNamespacedAttribute("xlink:href"), IsNamespacedAttribute("xml:"), IsNamespacedAttribute("href"))
+	// Output: true false false
+}
+
+func TestNamespacedAttributes(t *testing.T) {
+	a := NewAttributes().
+		Set("xlink:href", "#icon").
+		Set("xml:lang", "en").
+		Set("xmlns:xlink", "http://www.w3.org/1999/xlink")
+	want := `xlink:href="#icon" xml:lang="en" xmlns:xlink="http://www.w3.org/1999/xlink"`
+	if got := a.SortedString(); got != want {
+		t.Errorf("SortedString() = %v, want %v", got, want)
+	}
+	a2 := NewAttributes().MergeString(want)
+	if !reflect.DeepEqual(a, a2) {
+		t.Errorf("MergeString() = %v, want %v", a2, a)
+	}
+	if got := RenderVoidTag("use", Attributes{"xlink:href": "#icon"}); got != `<use xlink:href="#icon">` {
+		t.Errorf("RenderVoidTag() = %v", got)
+	}
+	for _, name := range []string{"foo:bar", "xlink:", ":class"} {
+		if _, err := NewAttributes().SetChanged(name, "1"); err == nil {
+			t.Errorf("SetChanged(%q) should return an error", name)
+		}
+		if _, err := ParseAttributes(name + `="1"`); err == nil {
+			t.Errorf("ParseAttributes(%q) should return an error", name)
+		}
+	}
+}
+
+func TestValueStringIntegers(t *testing.T) {
+	tests := []struct {
+		i    interface{}
+		want string
+	}{
+		{int8(-128), "-128"},
+		{int16(-32768), "-32768"},
+		{int32(-2147483648), "-2147483648"},
+		{int64(-9223372036854775808), "-9223372036854775808"},
+		{uint(18446744073709551615), "18446744073709551615"},
+		{uint8(255), "255"},
+		{uint16(65535), "65535"},
+		{uint32(4294967295), "4294967295"},
+		{uint64(18446744073709551615), "18446744073709551615"},
+	}
+	for _, tt := range tests {
+		if got := ValueString(tt.i); got != tt.want {
+			t.Errorf("ValueString(%T) = %v, want %v", tt.i, got, tt.want)
+		}
+	}
+}
+
+func ExampleAttributes_MergeDataJSON() {
+	defaults := Attributes{"data-config": `{"a":1,"b":{"c":2,"d":3}}`, "class": "x"}
+	instance := Attributes{"data-config": `{"b":{"d":4},"e":[5]}`, "class": "y"}
+	_ = defaults.MergeDataJSON(instance, "config")
+	fmt.Println(defaults.Class())
+	fmt.Println(defaults.Get("data-config"))
+	// Output: x y
+	// {"a":1,"b":{"c":2,"d":4},"e":[5]}
+}
+
+func TestAttributes_MergeDataJSON(t *testing.T) {
+	a := Attributes{"data-config": `{"a":1}`}
+	if err := a.MergeDataJSON(Attributes{"data-config": `{bad`}, "config"); err == nil {
+		t.Error("expected an error on bad json")
+	}
+	if a.Get("data-config") != `{"a":1}` {
+		t.Error("attributes should not change on error")
+	}
+	if err := a.MergeDataJSON(Attributes{"data-config": `[1]`}, "config"); err != nil || a.Get("data-config") != `[1]` {
+		t.Errorf("non-object should replace, got %v, %v", a, err)
+	}
+	a = Attributes{"id": "a"}
+	if err := a.MergeDataJSON(Attributes{"data-config": `{"a":1}`}, "config"); err != nil || a.Get("data-config") != `{"a":1}` {
+		t.Errorf("missing value should merge normally, got %v, %v", a, err)
+	}
+	if err := a.MergeDataJSON(nil, "Bad"); err == nil {
+		t.Error("expected an error on a bad key")
+	}
+
+	// large integers keep their precision
+	a = Attributes{"data-config": `{"id":9007199254740993,"n":{"x":1.5}}`}
+	if err := a.MergeDataJSON(Attributes{"data-config": `{"big":9223372036854775807,"n":{"y":2}}`}, "config"); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.Get("data-config"); got != `{"big":9223372036854775807,"id":9007199254740993,"n":{"x":1.5,"y":2}}` {
+		t.Errorf("MergeDataJSON() = %s", got)
+	}
+	if err := a.MergeDataJSON(Attributes{"data-config": `{"a":1} {"b":2}`}, "config"); err == nil {
+		t.Error("expected an error on trailing json")
+	}
+}
+
+func ExampleAttributes_WriteToQuoted() {
+	a := Attributes{"id": "a", "title": `Tom's "cat"`, "disabled": ""}
+	b := strings.Builder{}
+	_, _ = a.WriteToQuoted(&b, '\'')
+	fmt.Println(b.String())
+	b.Reset()
+	_, _ = a.WriteToQuoted(&b, '"')
+	fmt.Println(b.String())
+	// Output: id='a' disabled title='Tom&#39;s "cat"'
+	// id="a" disabled title="Tom's &#34;cat&#34;"
+}
+
+func TestAttributes_WriteToQuoted(t *testing.T) {
+	a := Attributes{"id": "a", "title": "<&>"}
+	b := strings.Builder{}
+	if _, err := a.WriteToQuoted(&b, '`'); err == nil {
+		t.Error("expected an error on a bad quote")
+	}
+	n, err := a.WriteToQuoted(&b, '\'')
+	if err != nil || b.String() != `id='a' title='&lt;&amp;&gt;'` || n != int64(b.Len()) {
+		t.Errorf("WriteToQuoted() = %q, %d, %v", b.String(), n, err)
+	}
+	for i := 0; i < b.Len(); i++ {
+		w := newErrBuf(i)
+		n, err = a.WriteToQuoted(w, '\'')
+		if err == nil || n != int64(i) {
+			t.Errorf("WriteToQuoted() with error at %d = %d, %v", i, n, err)
+		}
+	}
+}
+
+func ExampleAttributes_ToMap() {
+	a := Attributes{"id": "a", "disabled": "", "hidden": FalseValue}
+	fmt.Println(a.ToMap())
+	// Output: map[disabled: id:a]
+}
+
+func ExampleIsExplicitEmptyAttribute() {
+	a := Attributes{"value": "", "required": ""}
+	fmt.Println(a.SortedString())
+	fmt.Println(string(a.AppendBytes(nil)))
+	fmt.Println(IsExplicitEmptyAttribute("value"), IsExplicitEmptyAttribute("required"))
+	// Output: value="" required
+	// value="" required
+	// true false
+}
+
+func TestIsExplicitEmptyAttribute(t *testing.T) {
+	if got := RenderVoidTag("img", Attributes{"alt": ""}); got != `<img alt="">` {
+		t.Errorf("RenderVoidTag() = %v", got)
+	}
+	b := strings.Builder{}
+	_, _ = Attributes{"value": ""}.WriteToQuoted(&b, '\'')
+	if b.String() != `value=''` {
+		t.Errorf("WriteToQuoted() = %v", b.String())
+	}
+	a := NewAttributes().MergeString(`value=""`)
+	if a.String() != `value=""` {
+		t.Errorf("round trip = %v", a.String())
+	}
+}
+
+func ExampleParseAttributes() {
+	a, _ := ParseAttributes(`id=a class="b c" title='say "hi"' href="/s?q=1&amp;p=2" disabled`)
+	fmt.Println(a.SortedString())
+	// Output: id="a" class="b c" disabled href="/s?q=1&amp;p=2" title="say &#34;hi&#34;"
+}
+
+func TestParseAttributesErrors(t *testing.T) {
+	if a, err := ParseAttributes(`style="a b"`); err != nil || a.Has("style") {
+		t.Errorf("a bad style should be skipped, got %v, %v", a, err)
+	}
+	if _, err := ParseAttributes(`a"b=1`); err == nil {
+		t.Error("expected an error on a quote in a name")
+	}
+	if a, err := ParseAttributes(`style=""`); err != nil || a.Has("style") {
+		t.Errorf("empty style should not be set, got %v, %v", a, err)
+	}
+	if a, err := ParseAttributes(""); err != nil || a != nil {
+		t.Errorf("ParseAttributes() of empty string = %v, %v", a, err)
+	}
+}
+
+func TestAttributes_MergeStringChanged(t *testing.T) {
+	a := Attributes{"class": "a", "id": "x"}
+	if changed, err := a.MergeStringChanged(`class="a"`); err != nil || changed {
+		t.Errorf("MergeStringChanged() = %v, %v, want no change", changed, err)
+	}
+	if changed, err := a.MergeStringChanged(`class="b" title=t`); err != nil || !changed || a.Class() != "a b" {
+		t.Errorf("MergeStringChanged() = %v, %v, %v", changed, err, a)
+	}
+	if changed, err := a.OverrideStringChanged(`class="c"`); err != nil || !changed || a.Class() != "c" {
+		t.Errorf("OverrideStringChanged() = %v, %v, %v", changed, err, a)
+	}
+	before := a.Copy()
+	if _, err := a.MergeStringChanged(`title="u" a"b=1`); err == nil {
+		t.Error("expected an error on an invalid name")
+	}
+	if _, err := a.OverrideStringChanged(`a'b`); err == nil {
+		t.Error("expected an error on an invalid name")
+	}
+	if !reflect.DeepEqual(a, before) {
+		t.Errorf("the attributes should not change on error, got %v", a)
+	}
+	if changed, err := a.MergeStringChanged(""); err != nil || changed {
+		t.Errorf("MergeStringChanged() of an empty string = %v, %v", changed, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MergeString to panic on an invalid name")
+		}
+	}()
+	a.MergeString(`a"b=1`)
+}
+
+func TestParseAttributes_ValidMarkup(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`data-Foo="1"`, `data-foo="1"`},
+		{`DATA-MY-VAL=2`, `data-my-val="2"`},
+		{`data-foo.bar="1" data-x="2"`, `data-foo.bar="1" data-x="2"`},
+		{`style="abc"`, ``},
+		{`style="color:red;abc;width:2px"`, `style="color:red;width:2px"`},
+		{`style="a:(b"`, ``},
+		{`class="a"/`, `class="a"`},
+		{`disabled/ id=x`, `id="x" disabled`},
+	}
+	for _, tt := range tests {
+		a, err := ParseAttributes(tt.in)
+		if err != nil || a.String() != tt.want {
+			t.Errorf("ParseAttributes(%q) = %s, %v, want %s", tt.in, a.String(), err, tt.want)
+		}
+		// the string input functions must not panic on valid markup
+		if got := NewAttributes().MergeString(tt.in).String(); got != tt.want {
+			t.Errorf("MergeString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAttributes_SetChangedName(t *testing.T) {
+	for _, name := range []string{"", `a"b`, "a'b", "a>b", "a/b", "a=b", "a\x00b", "a\tb"} {
+		a := NewAttributes()
+		if _, err := a.SetChanged(name, "1"); err == nil || a.Len() != 0 {
+			t.Errorf("SetChanged(%q) should return an error", name)
+		}
+		if a.WouldChange(name, "1") {
+			t.Errorf("WouldChange(%q) should be false", name)
+		}
+	}
+}
+
+func ExampleAttributes_DataQueryString() {
+	a := Attributes{"id": "x", "data-user-id": "5", "data-tab": "a&b c", "data-x-y": "1"}
+	fmt.Println(a.DataQueryString())
+	// Output: tab=a%26b+c&userId=5&x-y=1
+}
+
+func TestAttributes_WriteToOrder(t *testing.T) {
+	a := Attributes{"zeta": "1", "class": "c", "alpha": "2", "id": "i", "name": "n", "data-x": "3"}
+	var keys []string
+	a.Range(func(k string, v string) bool {
+		keys = append(keys, k+`="`+v+`"`)
+		return true
+	})
+	want := strings.Join(keys, " ")
+	for i := 0; i < 10; i++ {
+		if got := a.String(); got != want {
+			t.Fatalf("String() = %s, want Range order %s", got, want)
+		}
+	}
+	if got := a.SortedString(); got != want {
+		t.Errorf("SortedString() = %s, want %s", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package html5tag
 
 import (
+	"sort"
 	"strings"
 )
 
@@ -106,3 +107,67 @@ func HasWordWithPrefix(class string, prefix string) bool {
 	}
 	return false
 }
+
+// ClassMap returns a space separated class list made up of the keys in m whose value is true.
+// The classes are sorted so that the result is consistent.
+//
+// This lets you build a class list from a set of conditions:
+//
+//	class := ClassMap(map[string]bool{"active": isActive, "disabled": !enabled})
+func ClassMap(m map[string]bool) string {
+	var classes []string
+	for k, v := range m {
+		if v {
+			classes = append(classes, k)
+		}
+	}
+	sort.Strings(classes)
+	return strings.Join(classes, " ")
+}
+
+// breakpointOrder lists common breakpoint prefixes from smallest to largest.
+var breakpointOrder = map[string]int{
+	"":    0,
+	"xs":  1,
+	"sm":  2,
+	"md":  3,
+	"lg":  4,
+	"xl":  5,
+	"xxl": 6,
+	"2xl": 6,
+	"3xl": 7,
+}
+
+// BreakpointClasses converts a map of breakpoint names to classes into a class string in which each class is
+// prefixed with its breakpoint, like the responsive classes of Tailwind. For example,
+// {"md": "flex", "lg": "grid gap-4"} becomes "md:flex lg:grid lg:gap-4". Classes with an empty breakpoint
+// are not prefixed.
+//
+// Breakpoints are ordered from smallest to largest: xs, sm, md, lg, xl, 2xl, 3xl. Unknown breakpoints come after
+// these, in alphabetical order.
+func BreakpointClasses(m map[string]string) string {
+	breakpoints := make([]string, 0, len(m))
+	for k := range m {
+		breakpoints = append(breakpoints, k)
+	}
+	sort.Slice(breakpoints, func(i, j int) bool {
+		o1, ok1 := breakpointOrder[breakpoints[i]]
+		o2, ok2 := breakpointOrder[breakpoints[j]]
+		if ok1 && ok2 && o1 != o2 {
+			return o1 < o2
+		} else if ok1 != ok2 {
+			return ok1
+		}
+		return breakpoints[i] < breakpoints[j]
+	})
+	var classes []string
+	for _, b := range breakpoints {
+		for _, c := range strings.Fields(m[b]) {
+			if b != "" {
+				c = b + ":" + c
+			}
+			classes = append(classes, c)
+		}
+	}
+	return strings.Join(classes, " ")
+}
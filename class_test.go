@@ -30,7 +30,7 @@ func ExampleRemoveClassesWithPrefix() {
 	// Output: col4-other
 }
 
-func ExampleHasClassWithPrefix() {
+func ExampleHasWordWithPrefix() {
 	exists := HasWordWithPrefix("col-6 col-brk col4-other", "col4-")
 	fmt.Println(exists)
 	// Output: true
@@ -81,3 +81,14 @@ func TestHasClassWithPrefix(t *testing.T) {
 		})
 	}
 }
+
+func ExampleClassMap() {
+	classes := ClassMap(map[string]bool{"b": true, "c": false, "a": true})
+	fmt.Println(classes)
+	// Output: a b
+}
+
+func ExampleBreakpointClasses() {
+	fmt.Println(BreakpointClasses(map[string]string{"lg": "grid gap-4", "": "block", "md": "flex", "print": "hidden", "2xl": "grid-cols-4"}))
+	// Output: block md:flex lg:grid lg:gap-4 2xl:grid-cols-4 print:hidden
+}
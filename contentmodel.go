@@ -0,0 +1,360 @@
+package html5tag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ContentModelError describes a place where an html fragment breaks the HTML5 content model.
+type ContentModelError struct {
+	// Offset is the byte offset in the html of the tag that caused the error.
+	Offset int
+	// Tag is the name of the tag that caused the error.
+	Tag string
+	// Message describes the problem.
+	Message string
+}
+
+func (e *ContentModelError) Error() string {
+	return fmt.Sprintf("<%s> at offset %d %s", e.Tag, e.Offset, e.Message)
+}
+
+func makeSet(items ...string) map[string]bool {
+	m := make(map[string]bool, len(items))
+	for _, i := range items {
+		m[i] = true
+	}
+	return m
+}
+
+// allowedChildren lists the only tags that can be direct children of these tags.
+var allowedChildren = map[string]map[string]bool{
+	"ul":       makeSet("li", "script", "template"),
+	"ol":       makeSet("li", "script", "template"),
+	"menu":     makeSet("li", "script", "template"),
+	"dl":       makeSet("dt", "dd", "div", "script", "template"),
+	"table":    makeSet("caption", "colgroup", "thead", "tbody", "tfoot", "tr", "script", "template"),
+	"thead":    makeSet("tr", "script", "template"),
+	"tbody":    makeSet("tr", "script", "template"),
+	"tfoot":    makeSet("tr", "script", "template"),
+	"tr":       makeSet("td", "th", "script", "template"),
+	"colgroup": makeSet("col", "template"),
+	"select":   makeSet("option", "optgroup", "hr", "script", "template"),
+	"optgroup": makeSet("option", "script", "template"),
+}
+
+// requiredParents lists the only tags that can be the direct parent of these tags.
+var requiredParents = map[string]map[string]bool{
+	"li":         makeSet("ul", "ol", "menu"),
+	"dt":         makeSet("dl", "div"),
+	"dd":         makeSet("dl", "div"),
+	"tr":         makeSet("table", "thead", "tbody", "tfoot"),
+	"td":         makeSet("tr"),
+	"th":         makeSet("tr"),
+	"thead":      makeSet("table"),
+	"tbody":      makeSet("table"),
+	"tfoot":      makeSet("table"),
+	"caption":    makeSet("table"),
+	"colgroup":   makeSet("table"),
+	"option":     makeSet("select", "optgroup", "datalist"),
+	"optgroup":   makeSet("select"),
+	"summary":    makeSet("details"),
+	"legend":     makeSet("fieldset"),
+	"figcaption": makeSet("figure"),
+}
+
+// phrasingTags are tags that can only contain phrasing content.
+var phrasingTags = makeSet("p", "span", "em", "strong", "b", "i", "u", "s", "small", "label", "button",
+	"h1", "h2", "h3", "h4", "h5", "h6", "abbr", "cite", "code", "dfn", "kbd", "mark", "q", "samp",
+	"sub", "sup", "time", "var", "pre")
+
+// flowTags are tags that are flow content, but not phrasing content.
+var flowTags = makeSet("address", "article", "aside", "blockquote", "details", "dialog", "div", "dl",
+	"fieldset", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "main",
+	"menu", "nav", "ol", "p", "pre", "section", "table", "ul")
+
+// interactiveTags are tags that cannot be placed inside an a or button tag.
+var interactiveTags = makeSet("a", "button", "select", "textarea", "details", "iframe")
+
+// impliedEndTags lists, for a starting tag, the open tags at the top of the stack that it closes when
+// their end tags are left out.
+var impliedEndTags = map[string]map[string]bool{
+	"li":       makeSet("li"),
+	"dt":       makeSet("dt", "dd"),
+	"dd":       makeSet("dt", "dd"),
+	"option":   makeSet("option"),
+	"optgroup": makeSet("option", "optgroup"),
+	"tr":       makeSet("tr", "td", "th"),
+	"td":       makeSet("td", "th"),
+	"th":       makeSet("td", "th"),
+	"thead":    makeSet("thead", "tbody", "tfoot", "tr", "td", "th", "caption", "colgroup"),
+	"tbody":    makeSet("thead", "tbody", "tfoot", "tr", "td", "th", "caption", "colgroup"),
+	"tfoot":    makeSet("thead", "tbody", "tfoot", "tr", "td", "th", "caption", "colgroup"),
+	"p":        makeSet("p"),
+}
+
+// optionalEndTags are tags whose end tags can be left out.
+var optionalEndTags = makeSet("li", "dt", "dd", "p", "option", "optgroup", "tr", "td", "th", "thead",
+	"tbody", "tfoot", "caption", "colgroup", "rt", "rp", "html", "head", "body")
+
+// rawTextTags are tags whose content is not parsed as html.
+var rawTextTags = makeSet("script", "style", "textarea", "title")
+
+// ValidateContentModel checks an html fragment for common violations of the HTML5 content model, like a <ul>
+// whose children are not <li> tags, a <tr> outside of a table, an <option> outside of a <select>,
+// a <div> inside a <p>, or a link inside a link. Tags that are not closed and closing tags without a matching
+// open tag are also reported. Tags whose end tags are optional are closed the same way a browser would.
+//
+// Each violation is returned as a *ContentModelError, which includes the offset of the tag in the html.
+// Only a pragmatic subset of the specification is checked, and content inside svg and math tags is ignored.
+func ValidateContentModel(html string) []error {
+	v := contentValidator{}
+	scanTags(html, func(t scannedTag) {
+		if t.isEnd {
+			v.endTag(t.name, t.offset)
+			return
+		}
+		v.startTag(t.name, t.offset)
+		if voidTags[t.name] || t.selfClosing {
+			v.pop()
+		}
+	})
+	for _, e := range v.stack {
+		if !optionalEndTags[e.tag] {
+			v.addError(e.tag, e.offset, "is not closed")
+		}
+	}
+	return v.errs
+}
+
+// scannedTag is a tag found by scanTags.
+type scannedTag struct {
+	name string
+	// offset is the offset of the start of the tag
+	offset int
+	// attributes is the text of the attributes of an opening tag
+	attributes  string
+	isEnd       bool
+	selfClosing bool
+}
+
+// scanTags calls f for each opening and closing tag in html, in order. Comments, doctypes and the
+// content of script, style, textarea and title tags are skipped.
+func scanTags(html string, f func(t scannedTag)) {
+	s := html
+	for i := 0; i < len(s); {
+		j := strings.IndexByte(s[i:], '<')
+		if j == -1 {
+			return
+		}
+		i += j
+		rest := s[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end == -1 {
+				return
+			}
+			i += end + 7
+		case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+			end := strings.IndexByte(rest, '>')
+			if end == -1 {
+				return
+			}
+			i += end + 1
+		case strings.HasPrefix(rest, "</"):
+			name := scanTagName(rest[2:])
+			if name == "" {
+				i++
+				continue
+			}
+			end := strings.IndexByte(rest, '>')
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			f(scannedTag{name: name, offset: i, isEnd: true})
+			i += end + 1
+		default:
+			name := scanTagName(rest[1:])
+			if name == "" {
+				i++
+				continue
+			}
+			end, selfClosing := scanTagEnd(rest)
+			attributes := strings.TrimSuffix(strings.TrimSuffix(rest[1+len(name):end], ">"), "/")
+			f(scannedTag{name: name, offset: i, attributes: attributes, selfClosing: selfClosing})
+			i += end
+			if rawTextTags[name] && !selfClosing {
+				c := indexASCIIFold(s[i:], "</"+name)
+				if c == -1 {
+					return
+				}
+				i += c
+			}
+		}
+	}
+}
+
+// indexASCIIFold returns the offset of the first instance of needle in s, ignoring ASCII case, or -1 if
+// needle is not in s. Unlike searching a lower cased copy of s, the offset is always an offset into s.
+func indexASCIIFold(s, needle string) int {
+	for j := 0; j+len(needle) <= len(s); j++ {
+		k := 0
+		for k < len(needle) && asciiLower(s[j+k]) == asciiLower(needle[k]) {
+			k++
+		}
+		if k == len(needle) {
+			return j
+		}
+	}
+	return -1
+}
+
+func asciiLower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// scanTagName returns the lower case tag name at the start of s.
+func scanTagName(s string) string {
+	var i int
+	for i < len(s) && strings.IndexByte(" \t\n\r\f/>", s[i]) == -1 {
+		i++
+	}
+	if i == 0 || !(s[0] >= 'a' && s[0] <= 'z' || s[0] >= 'A' && s[0] <= 'Z') {
+		return ""
+	}
+	return strings.ToLower(s[:i])
+}
+
+// scanTagEnd returns the offset just past the end of the opening tag at the start of s, skipping over
+// quoted attribute values, and whether the tag was self-closing.
+func scanTagEnd(s string) (end int, selfClosing bool) {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1, s[i-1] == '/'
+		}
+	}
+	return len(s), false
+}
+
+type openElement struct {
+	tag    string
+	offset int
+}
+
+type contentValidator struct {
+	stack []openElement
+	errs  []error
+}
+
+func (v *contentValidator) addError(tag string, offset int, msg string) {
+	v.errs = append(v.errs, &ContentModelError{Offset: offset, Tag: tag, Message: msg})
+}
+
+func (v *contentValidator) top() string {
+	if len(v.stack) == 0 {
+		return ""
+	}
+	return v.stack[len(v.stack)-1].tag
+}
+
+func (v *contentValidator) pop() {
+	v.stack = v.stack[:len(v.stack)-1]
+}
+
+// isForeign returns true if we are inside of an svg or math tag.
+func (v *contentValidator) isForeign() bool {
+	for _, e := range v.stack {
+		if e.tag == "svg" || e.tag == "math" {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *contentValidator) startTag(tag string, offset int) {
+	for impliedEndTags[tag][v.top()] {
+		v.pop()
+	}
+	if !v.isForeign() {
+		v.check(tag, offset)
+	}
+	v.stack = append(v.stack, openElement{tag, offset})
+}
+
+func (v *contentValidator) check(tag string, offset int) {
+	parent := v.top()
+	if parent == "template" {
+		return
+	}
+	if allowed, ok := allowedChildren[parent]; ok && !allowed[tag] {
+		v.addError(tag, offset, fmt.Sprintf("is not allowed in <%s>", parent))
+	} else if parents, ok := requiredParents[tag]; ok && !parents[parent] {
+		if parent == "" {
+			v.addError(tag, offset, "must be inside of "+parentList(parents))
+		} else {
+			v.addError(tag, offset, fmt.Sprintf("must be inside of %s, not <%s>", parentList(parents), parent))
+		}
+	}
+	if flowTags[tag] {
+		for i := len(v.stack) - 1; i >= 0; i-- {
+			if p := v.stack[i].tag; p == "template" {
+				break
+			} else if phrasingTags[p] {
+				v.addError(tag, offset, fmt.Sprintf("is not allowed in <%s>, which can only contain phrasing content", p))
+				break
+			}
+		}
+	}
+	if interactiveTags[tag] {
+		for i := len(v.stack) - 1; i >= 0; i-- {
+			if p := v.stack[i].tag; p == "template" {
+				break
+			} else if p == "a" || p == "button" {
+				v.addError(tag, offset, fmt.Sprintf("is not allowed in <%s>", p))
+				break
+			}
+		}
+	}
+}
+
+func (v *contentValidator) endTag(tag string, offset int) {
+	for i := len(v.stack) - 1; i >= 0; i-- {
+		if v.stack[i].tag == tag {
+			for _, e := range v.stack[i+1:] {
+				if !optionalEndTags[e.tag] {
+					v.addError(e.tag, e.offset, "is not closed")
+				}
+			}
+			v.stack = v.stack[:i]
+			return
+		}
+	}
+	v.addError(tag, offset, "is a closing tag with no matching opening tag")
+}
+
+// parentList returns the names of the tags in the set as a readable, sorted list.
+func parentList(set map[string]bool) string {
+	var names []string
+	for k := range set {
+		names = append(names, "<"+k+">")
+	}
+	sort.Strings(names)
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
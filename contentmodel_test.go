@@ -0,0 +1,101 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleValidateContentModel() {
+	errs := ValidateContentModel(`<ul><div>a</div></ul><tr><td>b</td></tr>`)
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	// Output:
+	// <div> at offset 4 is not allowed in <ul>
+	// <tr> at offset 21 must be inside of <table>, <tbody>, <tfoot> or <thead>
+}
+
+func TestValidateContentModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		offsets []int
+	}{
+		{"empty", "", nil},
+		{"text", "hello", nil},
+		{"list", "<ul><li>a</li><li>b</ul>", nil},
+		{"list implied end", "<ol><li>a<li>b</ol>", nil},
+		{"list bad child", "<ul><p>a</p></ul>", []int{4}},
+		{"li outside list", "<div><li>a</li></div>", []int{5}},
+		{"table", "<table><thead><tr><th>a</thead><tbody><tr><td>b<td>c<tr><td>d</tbody></table>", nil},
+		{"table direct tr", "<table><tr><td>a</td></tr></table>", nil},
+		{"td outside tr", "<table><td>a</td></table>", []int{7}},
+		{"select", `<select name="a"><option>a<option selected>b<optgroup label="x"><option>c</select>`, nil},
+		{"option outside", "<div><option>a</option></div>", []int{5}},
+		{"select bad child", "<select><span>a</span></select>", []int{8}},
+		{"div in p", "<p>a<div>b</div></p>", []int{4}},
+		{"div in span", "<span><em><div>b</div></em></span>", []int{10}},
+		{"p in div", "<div><p>a</p></div>", nil},
+		{"nested a", `<a href="x"><a href="y">b</a></a>`, []int{12}},
+		{"button in a", `<a href="x"><span><button>b</button></span></a>`, []int{18}},
+		{"unclosed", "<div><span>a</div>", []int{5}},
+		{"unclosed at end", "<section>", []int{0}},
+		{"stray close", "a</div>", []int{1}},
+		{"void", `<p>a<br>b<img src="x"><input/></p>`, nil},
+		{"comment", "<!-- <li> --><!DOCTYPE html>", nil},
+		{"script", "<ul><script>if (a<b) {x = '<li>'}</script></ul>", nil},
+		{"quoted gt", `<div title="a>b"><li>x</li></div>`, []int{17}},
+		{"svg", `<p><svg><g><text>a</text></g></svg></p>`, nil},
+		{"template", "<ul><template><div>a</div></template></ul>", nil},
+		{"dl", "<dl><div><dt>a<dd>b</div></dl>", nil},
+		{"uppercase", "<UL><DIV></DIV></UL>", []int{4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateContentModel(tt.html)
+			if len(errs) != len(tt.offsets) {
+				t.Fatalf("ValidateContentModel() got %d errors %v, want %d", len(errs), errs, len(tt.offsets))
+			}
+			for i, err := range errs {
+				if e := err.(*ContentModelError); e.Offset != tt.offsets[i] {
+					t.Errorf("error %d at offset %d, want %d: %v", i, e.Offset, tt.offsets[i], err)
+				}
+			}
+		})
+	}
+}
+
+func Test_scanTagsRawTextOffsets(t *testing.T) {
+	// characters whose lower case forms have a different length, and invalid utf-8, must not throw off the offsets
+	for _, content := range []string{"İİİİ", "\xff\xfe\xff", "ſ</ſcript>"} {
+		html := "<script>" + content + "</SCRIPT><p>a</p>"
+		var names []string
+		scanTags(html, func(tag scannedTag) {
+			names = append(names, tag.name)
+			if html[tag.offset] != '<' {
+				t.Errorf("offset %d of %s does not point to a tag in %q", tag.offset, tag.name, html)
+			}
+		})
+		if strings.Join(names, ",") != "script,script,p,p" {
+			t.Errorf("scanTags(%q) found %v", html, names)
+		}
+	}
+}
+
+func Test_indexASCIIFold(t *testing.T) {
+	tests := []struct {
+		s, needle string
+		want      int
+	}{
+		{"abc</Script>", "</script", 3},
+		{"</scrip", "</script", -1},
+		{"İ</style", "</style", 2},
+		{"", "</a", -1},
+	}
+	for _, tt := range tests {
+		if got := indexASCIIFold(tt.s, tt.needle); got != tt.want {
+			t.Errorf("indexASCIIFold(%q, %q) = %d, want %d", tt.s, tt.needle, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,792 @@
+package html5tag
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// CopyOnWrite shares an Attributes collection, typically a set of defaults used by many tags, and
+// only copies it the first time the attributes are changed. Code that only reads the attributes
+// never allocates.
+//
+// CopyOnWrite has the methods of Attributes. The ones that change the attributes copy the shared attributes
+// the first time one of them actually changes something, and the ones that read or render the attributes use
+// the shared attributes until then. A change that would do nothing, like removing an attribute that is not there,
+// does not copy. Changes that are hard to predict, like Merge, are made to a copy that is only kept if
+// something changed:
+//
+//	var defaults = Attributes{"class": "btn"}
+//
+//	a := NewCopyOnWrite(defaults)
+//	if primary {
+//		a.AddClass("btn-primary")
+//	}
+//	s := a.RenderTagNoSpace("button", "OK")
+//
+// Use Attributes for a read-only view of the current attributes, and Mutable to get attributes
+// that are safe to pass to code that changes them.
+//
+// The shared attributes must not be changed while a CopyOnWrite is using them.
+type CopyOnWrite struct {
+	attr  Attributes
+	owned bool
+}
+
+// NewCopyOnWrite returns a CopyOnWrite that shares the given attributes until they are changed.
+// shared may be nil.
+func NewCopyOnWrite(shared Attributes) *CopyOnWrite {
+	return &CopyOnWrite{attr: shared}
+}
+
+// Attributes returns a read-only view of the current attributes, without copying them.
+// Until the attributes are changed, the view is of the shared attributes.
+func (c *CopyOnWrite) Attributes() FrozenAttributes {
+	return FrozenAttributes{c.attr}
+}
+
+// Mutable returns attributes that can be changed without affecting the shared attributes. The first
+// call makes a copy, and later calls return that same copy.
+func (c *CopyOnWrite) Mutable() Attributes {
+	if !c.owned {
+		a := make(Attributes, len(c.attr))
+		for k, v := range c.attr {
+			a[k] = v
+		}
+		c.attr = a
+		c.owned = true
+	}
+	return c.attr
+}
+
+// IsCopied returns true if the shared attributes have been copied.
+func (c *CopyOnWrite) IsCopied() bool {
+	return c.owned
+}
+
+// Len returns the number of attributes.
+func (c *CopyOnWrite) Len() int {
+	return c.attr.Len()
+}
+
+// Has returns true if the named attribute is present.
+func (c *CopyOnWrite) Has(attr string) bool {
+	return c.attr.Has(attr)
+}
+
+// Get returns the named attribute.
+func (c *CopyOnWrite) Get(attr string) string {
+	return c.attr.Get(attr)
+}
+
+// ID returns the value of the id attribute.
+func (c *CopyOnWrite) ID() string {
+	return c.attr.ID()
+}
+
+// Class returns the value of the class attribute.
+func (c *CopyOnWrite) Class() string {
+	return c.attr.Class()
+}
+
+// HasClass returns true if the class attribute contains the given class.
+func (c *CopyOnWrite) HasClass(class string) bool {
+	return c.attr.HasClass(class)
+}
+
+// GetStyle returns the value of the given style property.
+func (c *CopyOnWrite) GetStyle(name string) string {
+	return c.attr.GetStyle(name)
+}
+
+// DataAttribute returns the value of the data attribute with the given camelCase key.
+func (c *CopyOnWrite) DataAttribute(key string) string {
+	return c.attr.DataAttribute(key)
+}
+
+// Range calls f for each attribute, in the same order as Attributes.Range.
+func (c *CopyOnWrite) Range(f func(key string, value string) bool) {
+	c.attr.Range(f)
+}
+
+// String returns the attributes escaped and encoded, ready to be placed in an HTML tag.
+func (c *CopyOnWrite) String() string {
+	return c.attr.String()
+}
+
+// WriteTo writes the attributes escaped and encoded.
+func (c *CopyOnWrite) WriteTo(w io.Writer) (n int64, err error) {
+	return c.attr.WriteTo(w)
+}
+
+// RenderTag renders a tag with the current attributes, without copying them. See the RenderTag function.
+func (c *CopyOnWrite) RenderTag(tag string, innerHtml string) string {
+	return RenderTag(tag, c.attr, innerHtml)
+}
+
+// RenderTagNoSpace renders a tag with the current attributes, without copying them.
+// See the RenderTagNoSpace function.
+func (c *CopyOnWrite) RenderTagNoSpace(tag string, innerHtml string) string {
+	return RenderTagNoSpace(tag, c.attr, innerHtml)
+}
+
+// RenderVoidTag renders a void tag with the current attributes, without copying them.
+// See the RenderVoidTag function.
+func (c *CopyOnWrite) RenderVoidTag(tag string) string {
+	return RenderVoidTag(tag, c.attr)
+}
+
+// WriteTag writes a tag with the current attributes to w, without copying them. See the WriteTag function.
+func (c *CopyOnWrite) WriteTag(w io.Writer, tag string, innerHtml io.WriterTo) (n int, err error) {
+	return WriteTag(w, tag, c.attr, innerHtml)
+}
+
+// WriteVoidTag writes a void tag with the current attributes to w, without copying them.
+// See the WriteVoidTag function.
+func (c *CopyOnWrite) WriteVoidTag(w io.Writer, tag string) (n int, err error) {
+	return WriteVoidTag(w, tag, c.attr)
+}
+
+// target returns the attributes that a change should be made to. If willChange is false, the change does not
+// write to the attributes, so the shared attributes are returned rather than a copy.
+func (c *CopyOnWrite) target(willChange bool) Attributes {
+	if willChange {
+		return c.Mutable()
+	}
+	return c.attr
+}
+
+// change makes a change that is hard to predict. f makes the change and returns true if it changed the attributes.
+// Until the shared attributes are copied, f is given a copy, which is only kept if f changed it.
+func (c *CopyOnWrite) change(f func(a Attributes) bool) bool {
+	if c.owned {
+		return f(c.attr)
+	}
+	a := c.attr.Copy()
+	if !f(a) {
+		return false
+	}
+	c.attr = a
+	c.owned = true
+	return true
+}
+
+// update is like change, for a change that does not report whether it changed the attributes.
+func (c *CopyOnWrite) update(f func(a Attributes)) {
+	c.change(func(a Attributes) bool {
+		f(a)
+		return c.owned || !equalAttributes(a, c.attr)
+	})
+}
+
+// equalAttributes returns true if a and b have the same attributes with the same values.
+func equalAttributes(a, b Attributes) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if v2, ok := b[k]; !ok || v2 != v {
+			return false
+		}
+	}
+	return true
+}
+
+// wouldSet returns true if setting the named attribute to v would change it.
+func (c *CopyOnWrite) wouldSet(name string, v string) bool {
+	old, ok := c.attr[name]
+	return !ok || old != v
+}
+
+// wouldAddValues returns true if AddValuesChanged would change the attributes.
+func (c *CopyOnWrite) wouldAddValues(attr string, values string) bool {
+	if values == "" {
+		return false
+	}
+	old, ok := c.attr[attr]
+	if ok && (isLazyValue(old) || isLazyValue(values)) {
+		return old != values
+	}
+	return !ok || MergeWords(old, values) != old
+}
+
+// HasAttributeValue returns true if the given value exists in the space-separated attribute value.
+func (c *CopyOnWrite) HasAttributeValue(attr string, value string) bool {
+	return c.attr.HasAttributeValue(attr, value)
+}
+
+// HasClassWithPrefix returns true if the class attribute has a class that starts with prefix.
+func (c *CopyOnWrite) HasClassWithPrefix(prefix string) bool {
+	return c.attr.HasClassWithPrefix(prefix)
+}
+
+// HasDataAttribute returns true if the data attribute with the given camelCase key is set.
+func (c *CopyOnWrite) HasDataAttribute(key string) bool {
+	return c.attr.HasDataAttribute(key)
+}
+
+// DataStrings returns the list of strings stored in the given data attribute. See Attributes.DataStrings.
+func (c *CopyOnWrite) DataStrings(name string) []string {
+	return c.attr.DataStrings(name)
+}
+
+// DataQueryString returns the data attributes encoded as a url query string. See Attributes.DataQueryString.
+func (c *CopyOnWrite) DataQueryString() string {
+	return c.attr.DataQueryString()
+}
+
+// HasStyle returns true if the given style property is set.
+func (c *CopyOnWrite) HasStyle(name string) bool {
+	return c.attr.HasStyle(name)
+}
+
+// StyleString returns the value of the style attribute.
+func (c *CopyOnWrite) StyleString() string {
+	return c.attr.StyleString()
+}
+
+// StyleMap returns a copy of the style attribute as a Style.
+func (c *CopyOnWrite) StyleMap() Style {
+	return c.attr.StyleMap()
+}
+
+// IsDisabled returns true if the disabled attribute is set.
+func (c *CopyOnWrite) IsDisabled() bool {
+	return c.attr.IsDisabled()
+}
+
+// IsDisplayed returns true if the display style property is not set to "none".
+func (c *CopyOnWrite) IsDisplayed() bool {
+	return c.attr.IsDisplayed()
+}
+
+// IsBooleanTrue returns true if the named attribute is a boolean attribute that is set. See Attributes.IsBooleanTrue.
+func (c *CopyOnWrite) IsBooleanTrue(name string) bool {
+	return c.attr.IsBooleanTrue(name)
+}
+
+// IsBooleanFalse returns true if the named attribute is set to FalseValue. See Attributes.IsBooleanFalse.
+func (c *CopyOnWrite) IsBooleanFalse(name string) bool {
+	return c.attr.IsBooleanFalse(name)
+}
+
+// WouldChange returns true if calling SetChanged with the given name and value would change the attributes.
+func (c *CopyOnWrite) WouldChange(name string, v string) bool {
+	return c.attr.WouldChange(name, v)
+}
+
+// Copy returns a copy of the current attributes, which can be changed without affecting the CopyOnWrite.
+func (c *CopyOnWrite) Copy() Attributes {
+	return c.attr.Copy()
+}
+
+// Freeze returns a read-only copy of the current attributes. See Attributes.Freeze.
+func (c *CopyOnWrite) Freeze() FrozenAttributes {
+	return c.attr.Freeze()
+}
+
+// ToMap returns a copy of the attributes as a plain map. See Attributes.ToMap.
+func (c *CopyOnWrite) ToMap() map[string]string {
+	return c.attr.ToMap()
+}
+
+// Resolve returns a read-only view of the attributes with the lazy values resolved. See Attributes.Resolve.
+func (c *CopyOnWrite) Resolve(ctx context.Context) FrozenAttributes {
+	return FrozenAttributes{c.attr.Resolve(ctx)}
+}
+
+// Diff returns the changes needed to turn the attributes into other. See Attributes.Diff.
+func (c *CopyOnWrite) Diff(other Attributes) AttributeDiff {
+	return c.attr.Diff(other)
+}
+
+// Union returns a new set of attributes, which is the attributes merged with other. See Attributes.Union.
+func (c *CopyOnWrite) Union(other Attributes) Attributes {
+	return c.attr.Union(other)
+}
+
+// Intersect returns a new set of the attributes that are also in other. See Attributes.Intersect.
+func (c *CopyOnWrite) Intersect(other Attributes) Attributes {
+	return c.attr.Intersect(other)
+}
+
+// Partition splits the attributes into two new sets. See Attributes.Partition.
+func (c *CopyOnWrite) Partition(pred func(name string) bool) (matched Attributes, rest Attributes) {
+	return c.attr.Partition(pred)
+}
+
+// ConflictingStyles returns the style properties that are also set by the classes. See Attributes.ConflictingStyles.
+func (c *CopyOnWrite) ConflictingStyles(classToProperty map[string]string) []string {
+	return c.attr.ConflictingStyles(classToProperty)
+}
+
+// ReferencedIDs returns the ids that the attributes refer to. See Attributes.ReferencedIDs.
+func (c *CopyOnWrite) ReferencedIDs() []string {
+	return c.attr.ReferencedIDs()
+}
+
+// ValidateForTag returns the attributes that are not allowed on the given tag. See Attributes.ValidateForTag.
+func (c *CopyOnWrite) ValidateForTag(tag string) []string {
+	return c.attr.ValidateForTag(tag)
+}
+
+// Selector returns a css selector that matches an element with the given tag and attributes.
+// See Attributes.Selector.
+func (c *CopyOnWrite) Selector(tag string) string {
+	return c.attr.Selector(tag)
+}
+
+// ApproxSize returns the approximate number of bytes of memory the attributes use.
+func (c *CopyOnWrite) ApproxSize() int {
+	return c.attr.ApproxSize()
+}
+
+// SortedString returns the attributes escaped and encoded. It is the same as String.
+func (c *CopyOnWrite) SortedString() string {
+	return c.attr.SortedString()
+}
+
+// DebugString returns the attributes in a canonical form. See Attributes.DebugString.
+func (c *CopyOnWrite) DebugString() string {
+	return c.attr.DebugString()
+}
+
+// JSXString returns the attributes the way they would be written in a React JSX tag. See Attributes.JSXString.
+func (c *CopyOnWrite) JSXString() string {
+	return c.attr.JSXString()
+}
+
+// AppendBytes appends the attributes, escaped and encoded, to dst. See Attributes.AppendBytes.
+func (c *CopyOnWrite) AppendBytes(dst []byte) []byte {
+	return c.attr.AppendBytes(dst)
+}
+
+// WriteSortedTo writes the attributes escaped and encoded. It is the same as WriteTo.
+func (c *CopyOnWrite) WriteSortedTo(w io.Writer) (n int64, err error) {
+	return c.attr.WriteSortedTo(w)
+}
+
+// WriteOrdered writes the attributes with the named keys first. See Attributes.WriteOrdered.
+func (c *CopyOnWrite) WriteOrdered(w io.Writer, order []string) (n int64, err error) {
+	return c.attr.WriteOrdered(w, order)
+}
+
+// WriteToQuoted writes the attributes using the given quote character. See Attributes.WriteToQuoted.
+func (c *CopyOnWrite) WriteToQuoted(w io.Writer, quote byte) (n int64, err error) {
+	return c.attr.WriteToQuoted(w, quote)
+}
+
+// SetChanged sets the value of an attribute and returns true if something changed. See Attributes.SetChanged.
+func (c *CopyOnWrite) SetChanged(name string, v string) (changed bool, err error) {
+	return c.target(c.attr.WouldChange(name, v)).SetChanged(name, v)
+}
+
+// Set sets the value of an attribute. See Attributes.Set.
+func (c *CopyOnWrite) Set(name string, v string) *CopyOnWrite {
+	c.target(c.attr.WouldChange(name, v)).Set(name, v)
+	return c
+}
+
+// SetAttributeFunc sets the attribute to a value that is resolved when rendering. See Attributes.SetAttributeFunc.
+func (c *CopyOnWrite) SetAttributeFunc(attr string, funcName string) *CopyOnWrite {
+	c.target(c.attr.WouldChange(attr, lazyPrefix+funcName)).SetAttributeFunc(attr, funcName)
+	return c
+}
+
+// Remove deletes the named attribute.
+func (c *CopyOnWrite) Remove(attr string) {
+	if c.attr.Has(attr) {
+		c.Mutable().Remove(attr)
+	}
+}
+
+// RemoveAttribute removes the named attribute and returns true if it was there.
+func (c *CopyOnWrite) RemoveAttribute(name string) bool {
+	return c.target(c.attr.Has(name)).RemoveAttribute(name)
+}
+
+// Override merges the given attributes, with conflicts won by the given attributes. See Attributes.Override.
+func (c *CopyOnWrite) Override(overrides Attributes) *CopyOnWrite {
+	c.update(func(a Attributes) { a.Override(overrides) })
+	return c
+}
+
+// Merge merges the given attributes, combining classes and styles. See Attributes.Merge.
+func (c *CopyOnWrite) Merge(aIn Attributes) *CopyOnWrite {
+	c.update(func(a Attributes) { a.Merge(aIn) })
+	return c
+}
+
+// MergeString merges attributes given in html format. See Attributes.MergeString.
+func (c *CopyOnWrite) MergeString(s string) *CopyOnWrite {
+	c.update(func(a Attributes) { a.MergeString(s) })
+	return c
+}
+
+// MergeStringChanged merges attributes given in html format, and returns true if something changed.
+// See Attributes.MergeStringChanged.
+func (c *CopyOnWrite) MergeStringChanged(s string) (changed bool, err error) {
+	c.change(func(a Attributes) bool {
+		changed, err = a.MergeStringChanged(s)
+		return changed
+	})
+	return
+}
+
+// OverrideString overrides attributes with attributes given in html format. See Attributes.OverrideString.
+func (c *CopyOnWrite) OverrideString(s string) *CopyOnWrite {
+	c.update(func(a Attributes) { a.OverrideString(s) })
+	return c
+}
+
+// OverrideStringChanged overrides attributes with attributes given in html format, and returns true if
+// something changed. See Attributes.OverrideStringChanged.
+func (c *CopyOnWrite) OverrideStringChanged(s string) (changed bool, err error) {
+	c.change(func(a Attributes) bool {
+		changed, err = a.OverrideStringChanged(s)
+		return changed
+	})
+	return
+}
+
+// MergeDataJSON merges other, deep merging the json in the given data attribute. See Attributes.MergeDataJSON.
+func (c *CopyOnWrite) MergeDataJSON(other Attributes, dataKey string) (err error) {
+	c.update(func(a Attributes) { err = a.MergeDataJSON(other, dataKey) })
+	return
+}
+
+// Normalize lower cases the attribute names and returns true if something changed. See Attributes.Normalize.
+func (c *CopyOnWrite) Normalize() bool {
+	return c.change(func(a Attributes) bool { return a.Normalize() })
+}
+
+// NormalizeVisibility reconciles the hidden attribute and the display style, and returns true if something changed.
+// See Attributes.NormalizeVisibility.
+func (c *CopyOnWrite) NormalizeVisibility(mode VisibilityMode) bool {
+	return c.change(func(a Attributes) bool { return a.NormalizeVisibility(mode) })
+}
+
+// Intern replaces the keys and values with interned strings. See Attributes.Intern.
+// Since every string is replaced, this always copies the shared attributes.
+func (c *CopyOnWrite) Intern(in *Interner) *CopyOnWrite {
+	c.Mutable().Intern(in)
+	return c
+}
+
+// SetIDChanged sets the id and returns true if something changed. See Attributes.SetIDChanged.
+func (c *CopyOnWrite) SetIDChanged(id string) (changed bool, err error) {
+	return c.target(c.attr.WouldChange("id", id)).SetIDChanged(id)
+}
+
+// SetID sets the id attribute. See Attributes.SetID.
+func (c *CopyOnWrite) SetID(id string) *CopyOnWrite {
+	c.target(c.attr.WouldChange("id", id)).SetID(id)
+	return c
+}
+
+// SetGeneratedID sets the id attribute to the next id of gen. See Attributes.SetGeneratedID.
+func (c *CopyOnWrite) SetGeneratedID(gen *IDGenerator, prefix string) *CopyOnWrite {
+	return c.SetID(gen.Next(prefix))
+}
+
+// SetClassChanged sets the class attribute and returns true if something changed.
+// See Attributes.SetClassChanged.
+func (c *CopyOnWrite) SetClassChanged(v string) bool {
+	return c.target(c.attr.WouldChange("class", v)).SetClassChanged(v)
+}
+
+// SetClass sets the class attribute. See Attributes.SetClass.
+func (c *CopyOnWrite) SetClass(v string) *CopyOnWrite {
+	c.target(c.attr.WouldChange("class", v)).SetClass(v)
+	return c
+}
+
+// AddClassChanged adds the given classes and returns true if something changed.
+func (c *CopyOnWrite) AddClassChanged(v string) bool {
+	return c.target(c.wouldAddValues("class", v)).AddClassChanged(v)
+}
+
+// AddClass adds the given classes to the class attribute.
+func (c *CopyOnWrite) AddClass(v string) *CopyOnWrite {
+	c.target(c.wouldAddValues("class", v)).AddClass(v)
+	return c
+}
+
+// AddValuesChanged adds space separated values to the end of an attribute value, and returns true if
+// something changed. See Attributes.AddValuesChanged.
+func (c *CopyOnWrite) AddValuesChanged(attr string, values string) bool {
+	return c.target(c.wouldAddValues(attr, values)).AddValuesChanged(attr, values)
+}
+
+// AddValues adds space separated values to the end of an attribute value. See Attributes.AddValues.
+func (c *CopyOnWrite) AddValues(attr string, values string) *CopyOnWrite {
+	c.target(c.wouldAddValues(attr, values)).AddValues(attr, values)
+	return c
+}
+
+// RemoveClass removes the given classes and returns true if something changed.
+func (c *CopyOnWrite) RemoveClass(v string) bool {
+	old, ok := c.attr["class"]
+	return c.target(ok && RemoveWords(old, v) != old).RemoveClass(v)
+}
+
+// RemoveClassesWithPrefix removes the classes that start with the given prefix, and returns true if something changed.
+func (c *CopyOnWrite) RemoveClassesWithPrefix(prefix string) bool {
+	old, ok := c.attr["class"]
+	return c.target(ok && RemoveClassesWithPrefix(old, prefix) != old).RemoveClassesWithPrefix(prefix)
+}
+
+// RemapClasses replaces classes using mapping, and returns true if something changed. See Attributes.RemapClasses.
+func (c *CopyOnWrite) RemapClasses(mapping map[string]string) bool {
+	return c.change(func(a Attributes) bool { return a.RemapClasses(mapping) })
+}
+
+// SetClassMap sets the class attribute to the keys in m whose value is true. See Attributes.SetClassMap.
+func (c *CopyOnWrite) SetClassMap(m map[string]bool) *CopyOnWrite {
+	c.target(c.attr.WouldChange("class", ClassMap(m))).SetClassMap(m)
+	return c
+}
+
+// wouldSetData returns true if SetDataChanged would change the attributes.
+func (c *CopyOnWrite) wouldSetData(name string, v string) bool {
+	if strings.ContainsAny(name, " !$") {
+		return false
+	}
+	suffix, err := ToDataAttr(name)
+	return err == nil && c.wouldSet("data-"+suffix, v)
+}
+
+// SetDataChanged sets the given data attribute and returns true if something changed.
+// See Attributes.SetDataChanged.
+func (c *CopyOnWrite) SetDataChanged(name string, v string) (changed bool, err error) {
+	return c.target(c.wouldSetData(name, v)).SetDataChanged(name, v)
+}
+
+// SetData sets the given data attribute. See Attributes.SetData.
+func (c *CopyOnWrite) SetData(name string, v string) *CopyOnWrite {
+	c.target(c.wouldSetData(name, v)).SetData(name, v)
+	return c
+}
+
+// SetDataStrings sets the given data attribute to a list of strings. See Attributes.SetDataStrings.
+func (c *CopyOnWrite) SetDataStrings(name string, values []string) *CopyOnWrite {
+	c.update(func(a Attributes) { a.SetDataStrings(name, values) })
+	return c
+}
+
+// RemoveDataAttribute removes the data attribute with the given camelCase key, and returns true if it was there.
+func (c *CopyOnWrite) RemoveDataAttribute(key string) bool {
+	return c.target(c.attr.HasDataAttribute(key)).RemoveDataAttribute(key)
+}
+
+// wouldSetStyle returns true if SetStyleChanged would write to the attributes.
+func (c *CopyOnWrite) wouldSetStyle(name string, v string) bool {
+	s := c.attr.StyleMap()
+	if _, err := s.SetChanged(name, v); err != nil {
+		return false
+	}
+	return c.wouldSet("style", s.String())
+}
+
+// SetStyleChanged sets the given style property and returns true if something changed.
+// See Attributes.SetStyleChanged.
+func (c *CopyOnWrite) SetStyleChanged(name string, v string) (changed bool, err error) {
+	return c.target(c.wouldSetStyle(name, v)).SetStyleChanged(name, v)
+}
+
+// SetStyle sets the given style property. See Attributes.SetStyle.
+func (c *CopyOnWrite) SetStyle(name string, v string) *CopyOnWrite {
+	c.target(c.wouldSetStyle(name, v)).SetStyle(name, v)
+	return c
+}
+
+// SetStyles merges the given styles into the style attribute. See Attributes.SetStyles.
+func (c *CopyOnWrite) SetStyles(s Style) *CopyOnWrite {
+	c.update(func(a Attributes) { a.SetStyles(s) })
+	return c
+}
+
+// SetStyleMap sets the style attribute to the given styles. See Attributes.SetStyleMap.
+func (c *CopyOnWrite) SetStyleMap(s Style) *CopyOnWrite {
+	c.update(func(a Attributes) { a.SetStyleMap(s) })
+	return c
+}
+
+// SetStylesTo merges styles given in css format into the style attribute. See Attributes.SetStylesTo.
+func (c *CopyOnWrite) SetStylesTo(s string) *CopyOnWrite {
+	c.update(func(a Attributes) { a.SetStylesTo(s) })
+	return c
+}
+
+// RemoveStyle removes the given style property and returns true if something changed.
+func (c *CopyOnWrite) RemoveStyle(name string) bool {
+	return c.target(c.attr.HasStyle(name)).RemoveStyle(name)
+}
+
+// SetDisabled sets or removes the disabled attribute. See Attributes.SetDisabled.
+func (c *CopyOnWrite) SetDisabled(d bool) *CopyOnWrite {
+	c.target(d && c.wouldSet("disabled", "") || !d && c.attr.Has("disabled")).SetDisabled(d)
+	return c
+}
+
+// SetDisplay sets the display style property. See Attributes.SetDisplay.
+func (c *CopyOnWrite) SetDisplay(d string) *CopyOnWrite {
+	return c.SetStyle("display", d)
+}
+
+// SetSpellcheck sets the spellcheck attribute. See Attributes.SetSpellcheck.
+func (c *CopyOnWrite) SetSpellcheck(check bool) *CopyOnWrite {
+	v := "false"
+	if check {
+		v = "true"
+	}
+	c.target(c.wouldSet("spellcheck", v)).SetSpellcheck(check)
+	return c
+}
+
+// SetDir sets the dir attribute. See Attributes.SetDir.
+func (c *CopyOnWrite) SetDir(dir string) (_ *CopyOnWrite, err error) {
+	c.update(func(a Attributes) { _, err = a.SetDir(dir) })
+	return c, err
+}
+
+// SetLang sets the lang attribute. See Attributes.SetLang.
+func (c *CopyOnWrite) SetLang(tag string) (_ *CopyOnWrite, err error) {
+	c.update(func(a Attributes) { _, err = a.SetLang(tag) })
+	return c, err
+}
+
+// SetAutocapitalizeChanged sets the autocapitalize attribute and returns true if something changed.
+// See Attributes.SetAutocapitalizeChanged.
+func (c *CopyOnWrite) SetAutocapitalizeChanged(token string) (changed bool, err error) {
+	c.change(func(a Attributes) bool {
+		changed, err = a.SetAutocapitalizeChanged(token)
+		return changed
+	})
+	return
+}
+
+// SetAutocapitalize sets the autocapitalize attribute. See Attributes.SetAutocapitalize.
+func (c *CopyOnWrite) SetAutocapitalize(token string) *CopyOnWrite {
+	if _, err := c.SetAutocapitalizeChanged(token); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// SetContentEditableChanged sets the contenteditable attribute and returns true if something changed.
+// See Attributes.SetContentEditableChanged.
+func (c *CopyOnWrite) SetContentEditableChanged(mode string) (changed bool, err error) {
+	c.change(func(a Attributes) bool {
+		changed, err = a.SetContentEditableChanged(mode)
+		return changed
+	})
+	return
+}
+
+// SetContentEditable sets the contenteditable attribute. See Attributes.SetContentEditable.
+func (c *CopyOnWrite) SetContentEditable(mode string) *CopyOnWrite {
+	if _, err := c.SetContentEditableChanged(mode); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// SetEventHandlerChanged sets the handler of the given DOM event and returns true if something changed.
+// See Attributes.SetEventHandlerChanged.
+func (c *CopyOnWrite) SetEventHandlerChanged(event string, js string) (changed bool, err error) {
+	c.change(func(a Attributes) bool {
+		changed, err = a.SetEventHandlerChanged(event, js)
+		return changed
+	})
+	return
+}
+
+// SetEventHandler sets the handler of the given DOM event. See Attributes.SetEventHandler.
+func (c *CopyOnWrite) SetEventHandler(event string, js string) *CopyOnWrite {
+	if _, err := c.SetEventHandlerChanged(event, js); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// RemoveEventHandlers removes the event handler attributes and returns true if something changed.
+func (c *CopyOnWrite) RemoveEventHandlers() bool {
+	return c.change(func(a Attributes) bool { return a.RemoveEventHandlers() })
+}
+
+// setHx sets an htmx attribute with set, which is one of the SetHx*Changed methods of Attributes.
+func (c *CopyOnWrite) setHx(set func(a Attributes, v string) (bool, error), v string) (changed bool, err error) {
+	c.change(func(a Attributes) bool {
+		changed, err = set(a, v)
+		return changed
+	})
+	return
+}
+
+// SetHxGetChanged sets the hx-get attribute and returns true if something changed. See Attributes.SetHxGetChanged.
+func (c *CopyOnWrite) SetHxGetChanged(url string) (changed bool, err error) {
+	return c.setHx(Attributes.SetHxGetChanged, url)
+}
+
+// SetHxGet sets the hx-get attribute. See Attributes.SetHxGet.
+func (c *CopyOnWrite) SetHxGet(url string) *CopyOnWrite {
+	if _, err := c.SetHxGetChanged(url); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// SetHxPostChanged sets the hx-post attribute and returns true if something changed. See Attributes.SetHxPostChanged.
+func (c *CopyOnWrite) SetHxPostChanged(url string) (changed bool, err error) {
+	return c.setHx(Attributes.SetHxPostChanged, url)
+}
+
+// SetHxPost sets the hx-post attribute. See Attributes.SetHxPost.
+func (c *CopyOnWrite) SetHxPost(url string) *CopyOnWrite {
+	if _, err := c.SetHxPostChanged(url); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// SetHxSwapChanged sets the hx-swap attribute and returns true if something changed. See Attributes.SetHxSwapChanged.
+func (c *CopyOnWrite) SetHxSwapChanged(mode string) (changed bool, err error) {
+	return c.setHx(Attributes.SetHxSwapChanged, mode)
+}
+
+// SetHxSwap sets the hx-swap attribute. See Attributes.SetHxSwap.
+func (c *CopyOnWrite) SetHxSwap(mode string) *CopyOnWrite {
+	if _, err := c.SetHxSwapChanged(mode); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// SetHxTargetChanged sets the hx-target attribute and returns true if something changed.
+// See Attributes.SetHxTargetChanged.
+func (c *CopyOnWrite) SetHxTargetChanged(selector string) (changed bool, err error) {
+	return c.setHx(Attributes.SetHxTargetChanged, selector)
+}
+
+// SetHxTarget sets the hx-target attribute. See Attributes.SetHxTarget.
+func (c *CopyOnWrite) SetHxTarget(selector string) *CopyOnWrite {
+	if _, err := c.SetHxTargetChanged(selector); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// SetHxTriggerChanged sets the hx-trigger attribute and returns true if something changed.
+// See Attributes.SetHxTriggerChanged.
+func (c *CopyOnWrite) SetHxTriggerChanged(spec string) (changed bool, err error) {
+	return c.setHx(Attributes.SetHxTriggerChanged, spec)
+}
+
+// SetHxTrigger sets the hx-trigger attribute. See Attributes.SetHxTrigger.
+func (c *CopyOnWrite) SetHxTrigger(spec string) *CopyOnWrite {
+	if _, err := c.SetHxTriggerChanged(spec); err != nil {
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,244 @@
+package html5tag
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleCopyOnWrite() {
+	defaults := Attributes{"class": "btn"}
+
+	a := NewCopyOnWrite(defaults)
+	fmt.Println(a.RenderTagNoSpace("button", "Cancel"))
+
+	a.AddClass("btn-primary")
+	fmt.Println(a.RenderTagNoSpace("button", "OK"))
+	fmt.Println(defaults.Class())
+	// Output:
+	// <button class="btn">Cancel</button>
+	// <button class="btn btn-primary">OK</button>
+	// btn
+}
+
+func TestCopyOnWrite(t *testing.T) {
+	shared := Attributes{"id": "a", "class": "b"}
+	c := NewCopyOnWrite(shared)
+
+	if c.IsCopied() {
+		t.Error("expected no copy before mutation")
+	}
+	if c.Attributes().Get("id") != "a" || c.Get("id") != "a" || c.Class() != "b" {
+		t.Error("expected to read shared value")
+	}
+	_ = c.RenderTag("div", "")
+	if c.IsCopied() {
+		t.Error("reading should not copy")
+	}
+
+	m := c.Mutable()
+	m.SetID("c")
+	if !c.IsCopied() {
+		t.Error("expected copy after Mutable")
+	}
+	if shared.ID() != "a" {
+		t.Error("shared attributes were changed")
+	}
+	if c.Attributes().ID() != "c" {
+		t.Error("expected the copy to be returned")
+	}
+	c.Mutable().SetClass("d")
+	if m.Class() != "d" {
+		t.Error("expected Mutable to return the same copy")
+	}
+
+	n := NewCopyOnWrite(nil)
+	if n.Attributes().Len() != 0 {
+		t.Error("expected empty attributes")
+	}
+	n.Set("title", "x")
+	if n.Attributes().Get("title") != "x" {
+		t.Error("expected to set on a nil shared set")
+	}
+}
+
+func TestCopyOnWrite_Mutators(t *testing.T) {
+	shared := Attributes{"id": "a", "class": "b", "style": "color:red", "data-my-val": "1", "title": "t"}
+	want := shared.String()
+
+	tests := []struct {
+		name   string
+		mutate func(c *CopyOnWrite)
+		check  func(c *CopyOnWrite) bool
+	}{
+		{"Set", func(c *CopyOnWrite) { c.Set("title", "u") }, func(c *CopyOnWrite) bool { return c.Get("title") == "u" }},
+		{"SetChanged", func(c *CopyOnWrite) { _, _ = c.SetChanged("title", "u") }, func(c *CopyOnWrite) bool { return c.Get("title") == "u" }},
+		{"Remove", func(c *CopyOnWrite) { c.Remove("title") }, func(c *CopyOnWrite) bool { return !c.Has("title") }},
+		{"RemoveAttribute", func(c *CopyOnWrite) { c.RemoveAttribute("title") }, func(c *CopyOnWrite) bool { return !c.Has("title") }},
+		{"Override", func(c *CopyOnWrite) { c.Override(Attributes{"class": "z"}) }, func(c *CopyOnWrite) bool { return c.Class() == "z" }},
+		{"Merge", func(c *CopyOnWrite) { c.Merge(Attributes{"class": "z"}) }, func(c *CopyOnWrite) bool { return c.Class() == "b z" }},
+		{"SetID", func(c *CopyOnWrite) { c.SetID("z") }, func(c *CopyOnWrite) bool { return c.ID() == "z" }},
+		{"SetIDChanged", func(c *CopyOnWrite) { _, _ = c.SetIDChanged("z") }, func(c *CopyOnWrite) bool { return c.ID() == "z" }},
+		{"SetClass", func(c *CopyOnWrite) { c.SetClass("z") }, func(c *CopyOnWrite) bool { return c.Class() == "z" }},
+		{"SetClassChanged", func(c *CopyOnWrite) { c.SetClassChanged("z") }, func(c *CopyOnWrite) bool { return c.Class() == "z" }},
+		{"AddClass", func(c *CopyOnWrite) { c.AddClass("z") }, func(c *CopyOnWrite) bool { return c.HasClass("z") }},
+		{"AddClassChanged", func(c *CopyOnWrite) { c.AddClassChanged("z") }, func(c *CopyOnWrite) bool { return c.HasClass("z") }},
+		{"RemoveClass", func(c *CopyOnWrite) { c.RemoveClass("b") }, func(c *CopyOnWrite) bool { return !c.HasClass("b") }},
+		{"SetClassMap", func(c *CopyOnWrite) { c.SetClassMap(map[string]bool{"z": true}) }, func(c *CopyOnWrite) bool { return c.Class() == "z" }},
+		{"SetData", func(c *CopyOnWrite) { c.SetData("myVal", "2") }, func(c *CopyOnWrite) bool { return c.DataAttribute("myVal") == "2" }},
+		{"SetDataChanged", func(c *CopyOnWrite) { _, _ = c.SetDataChanged("myVal", "2") }, func(c *CopyOnWrite) bool { return c.DataAttribute("myVal") == "2" }},
+		{"RemoveDataAttribute", func(c *CopyOnWrite) { c.RemoveDataAttribute("myVal") }, func(c *CopyOnWrite) bool { return c.DataAttribute("myVal") == "" }},
+		{"SetStyle", func(c *CopyOnWrite) { c.SetStyle("color", "blue") }, func(c *CopyOnWrite) bool { return c.GetStyle("color") == "blue" }},
+		{"SetStyleChanged", func(c *CopyOnWrite) { _, _ = c.SetStyleChanged("color", "blue") }, func(c *CopyOnWrite) bool { return c.GetStyle("color") == "blue" }},
+		{"SetStyles", func(c *CopyOnWrite) { c.SetStyles(Style{"color": "blue"}) }, func(c *CopyOnWrite) bool { return c.GetStyle("color") == "blue" }},
+		{"RemoveStyle", func(c *CopyOnWrite) { c.RemoveStyle("color") }, func(c *CopyOnWrite) bool { return c.GetStyle("color") == "" }},
+		{"SetDisabled", func(c *CopyOnWrite) { c.SetDisabled(true) }, func(c *CopyOnWrite) bool { return c.Has("disabled") }},
+		{"SetDisplay", func(c *CopyOnWrite) { c.SetDisplay("none") }, func(c *CopyOnWrite) bool { return c.GetStyle("display") == "none" }},
+		{"AddValues", func(c *CopyOnWrite) { c.AddValues("title", "u") }, func(c *CopyOnWrite) bool { return c.Get("title") == "t u" }},
+		{"AddValuesChanged", func(c *CopyOnWrite) { c.AddValuesChanged("rel", "u") }, func(c *CopyOnWrite) bool { return c.Get("rel") == "u" }},
+		{"MergeString", func(c *CopyOnWrite) { c.MergeString(`class="z"`) }, func(c *CopyOnWrite) bool { return c.Class() == "b z" }},
+		{"MergeStringChanged", func(c *CopyOnWrite) { _, _ = c.MergeStringChanged(`class="z"`) }, func(c *CopyOnWrite) bool { return c.Class() == "b z" }},
+		{"OverrideString", func(c *CopyOnWrite) { c.OverrideString(`class="z"`) }, func(c *CopyOnWrite) bool { return c.Class() == "z" }},
+		{"OverrideStringChanged", func(c *CopyOnWrite) { _, _ = c.OverrideStringChanged(`class="z"`) }, func(c *CopyOnWrite) bool { return c.Class() == "z" }},
+		{"RemoveClassesWithPrefix", func(c *CopyOnWrite) { c.RemoveClassesWithPrefix("b") }, func(c *CopyOnWrite) bool { return !c.Has("class") || c.Class() == "" }},
+		{"RemapClasses", func(c *CopyOnWrite) { c.RemapClasses(map[string]string{"b": "z"}) }, func(c *CopyOnWrite) bool { return c.Class() == "z" }},
+		{"SetDataStrings", func(c *CopyOnWrite) { c.SetDataStrings("list", []string{"x"}) }, func(c *CopyOnWrite) bool { return c.DataStrings("list")[0] == "x" }},
+		{"SetStyleMap", func(c *CopyOnWrite) { c.SetStyleMap(Style{"color": "blue"}) }, func(c *CopyOnWrite) bool { return c.StyleString() == "color:blue" }},
+		{"SetStylesTo", func(c *CopyOnWrite) { c.SetStylesTo("color:blue") }, func(c *CopyOnWrite) bool { return c.GetStyle("color") == "blue" }},
+		{"SetSpellcheck", func(c *CopyOnWrite) { c.SetSpellcheck(true) }, func(c *CopyOnWrite) bool { return c.Get("spellcheck") == "true" }},
+		{"SetDir", func(c *CopyOnWrite) { _, _ = c.SetDir("rtl") }, func(c *CopyOnWrite) bool { return c.Get("dir") == "rtl" }},
+		{"SetLang", func(c *CopyOnWrite) { _, _ = c.SetLang("en") }, func(c *CopyOnWrite) bool { return c.Get("lang") == "en" }},
+		{"SetAutocapitalize", func(c *CopyOnWrite) { c.SetAutocapitalize("words") }, func(c *CopyOnWrite) bool { return c.Get("autocapitalize") == "words" }},
+		{"SetContentEditable", func(c *CopyOnWrite) { c.SetContentEditable("true") }, func(c *CopyOnWrite) bool { return c.Get("contenteditable") == "true" }},
+		{"SetEventHandler", func(c *CopyOnWrite) { c.SetEventHandler("click", "f()") }, func(c *CopyOnWrite) bool { return c.Get("onclick") == "f()" }},
+		{"SetHxGet", func(c *CopyOnWrite) { c.SetHxGet("/a") }, func(c *CopyOnWrite) bool { return c.Get("hx-get") == "/a" }},
+		{"SetHxPost", func(c *CopyOnWrite) { c.SetHxPost("/a") }, func(c *CopyOnWrite) bool { return c.Get("hx-post") == "/a" }},
+		{"SetHxSwap", func(c *CopyOnWrite) { c.SetHxSwap("outerHTML") }, func(c *CopyOnWrite) bool { return c.Get("hx-swap") == "outerHTML" }},
+		{"SetHxTarget", func(c *CopyOnWrite) { c.SetHxTarget("#a") }, func(c *CopyOnWrite) bool { return c.Get("hx-target") == "#a" }},
+		{"SetHxTrigger", func(c *CopyOnWrite) { c.SetHxTrigger("load") }, func(c *CopyOnWrite) bool { return c.Get("hx-trigger") == "load" }},
+		{"SetAttributeFunc", func(c *CopyOnWrite) { c.SetAttributeFunc("title", "f") }, func(c *CopyOnWrite) bool { return c.Get("title") != "t" }},
+		{"SetGeneratedID", func(c *CopyOnWrite) { c.SetGeneratedID(NewIDGenerator(), "x") }, func(c *CopyOnWrite) bool { return c.ID() != "a" }},
+		{"Intern", func(c *CopyOnWrite) { c.Intern(NewInterner(0)) }, func(c *CopyOnWrite) bool { return c.ID() == "a" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCopyOnWrite(shared)
+			tt.mutate(c)
+			if !tt.check(c) {
+				t.Errorf("the change was not made: %s", c)
+			}
+			if !c.IsCopied() {
+				t.Error("expected a copy")
+			}
+			if shared.String() != want {
+				t.Errorf("the shared attributes were changed to %s", shared)
+			}
+		})
+	}
+}
+
+func TestCopyOnWrite_Render(t *testing.T) {
+	c := NewCopyOnWrite(Attributes{"id": "a"})
+	b := strings.Builder{}
+	if _, err := c.WriteTag(&b, "div", nil); err != nil || b.String() != RenderTag("div", Attributes{"id": "a"}, "") {
+		t.Errorf("WriteTag() = %s, %v", b.String(), err)
+	}
+	b.Reset()
+	if _, err := c.WriteVoidTag(&b, "br"); err != nil || b.String() != c.RenderVoidTag("br") {
+		t.Errorf("WriteVoidTag() = %s, %v", b.String(), err)
+	}
+	b.Reset()
+	if _, err := c.WriteTo(&b); err != nil || b.String() != c.String() {
+		t.Errorf("WriteTo() = %s, %v", b.String(), err)
+	}
+	if c.IsCopied() {
+		t.Error("rendering should not copy")
+	}
+}
+
+func TestCopyOnWrite_NoChange(t *testing.T) {
+	shared := Attributes{"id": "a", "class": "b c", "style": "color:red", "data-my-val": "1", "title": "t",
+		"disabled": "", "spellcheck": "true", "dir": "ltr", "hx-get": "/a"}
+	want := shared.String()
+
+	tests := []struct {
+		name   string
+		mutate func(c *CopyOnWrite)
+	}{
+		{"Set", func(c *CopyOnWrite) { c.Set("title", "t") }},
+		{"SetChanged", func(c *CopyOnWrite) { _, _ = c.SetChanged("title", "t") }},
+		{"SetChanged error", func(c *CopyOnWrite) { _, _ = c.SetChanged("a b", "t") }},
+		{"Remove", func(c *CopyOnWrite) { c.Remove("lang") }},
+		{"RemoveAttribute", func(c *CopyOnWrite) { c.RemoveAttribute("lang") }},
+		{"Override", func(c *CopyOnWrite) { c.Override(Attributes{"title": "t"}) }},
+		{"Merge", func(c *CopyOnWrite) { c.Merge(Attributes{"class": "b"}) }},
+		{"Merge nil", func(c *CopyOnWrite) { c.Merge(nil) }},
+		{"MergeString", func(c *CopyOnWrite) { c.MergeString(`class="c"`) }},
+		{"MergeStringChanged error", func(c *CopyOnWrite) { _, _ = c.MergeStringChanged(`"`) }},
+		{"OverrideStringChanged", func(c *CopyOnWrite) { _, _ = c.OverrideStringChanged(`id="a"`) }},
+		{"SetID", func(c *CopyOnWrite) { c.SetID("a") }},
+		{"SetIDChanged error", func(c *CopyOnWrite) { _, _ = c.SetIDChanged("a b") }},
+		{"SetClass", func(c *CopyOnWrite) { c.SetClass("b c") }},
+		{"SetClassMap", func(c *CopyOnWrite) { c.SetClassMap(map[string]bool{"b": true, "c": true}) }},
+		{"AddClass", func(c *CopyOnWrite) { c.AddClass("c") }},
+		{"AddClassChanged", func(c *CopyOnWrite) { c.AddClassChanged("") }},
+		{"AddValues", func(c *CopyOnWrite) { c.AddValues("title", "t") }},
+		{"RemoveClass", func(c *CopyOnWrite) { c.RemoveClass("z") }},
+		{"RemoveClassesWithPrefix", func(c *CopyOnWrite) { c.RemoveClassesWithPrefix("z") }},
+		{"RemapClasses", func(c *CopyOnWrite) { c.RemapClasses(map[string]string{"z": "y"}) }},
+		{"SetData", func(c *CopyOnWrite) { c.SetData("myVal", "1") }},
+		{"SetDataChanged error", func(c *CopyOnWrite) { _, _ = c.SetDataChanged("my val", "1") }},
+		{"RemoveDataAttribute", func(c *CopyOnWrite) { c.RemoveDataAttribute("other") }},
+		{"SetStyle", func(c *CopyOnWrite) { c.SetStyle("color", "red") }},
+		{"SetStyleChanged error", func(c *CopyOnWrite) { _, _ = c.SetStyleChanged("a b", "red") }},
+		{"SetStyles", func(c *CopyOnWrite) { c.SetStyles(Style{"color": "red"}) }},
+		{"RemoveStyle", func(c *CopyOnWrite) { c.RemoveStyle("width") }},
+		{"SetDisabled", func(c *CopyOnWrite) { c.SetDisabled(true) }},
+		{"SetSpellcheck", func(c *CopyOnWrite) { c.SetSpellcheck(true) }},
+		{"SetDir", func(c *CopyOnWrite) { _, _ = c.SetDir("ltr") }},
+		{"SetDir error", func(c *CopyOnWrite) { _, _ = c.SetDir("up") }},
+		{"SetHxGetChanged", func(c *CopyOnWrite) { _, _ = c.SetHxGetChanged("/a") }},
+		{"SetEventHandlerChanged", func(c *CopyOnWrite) { _, _ = c.SetEventHandlerChanged("click", "") }},
+		{"RemoveEventHandlers", func(c *CopyOnWrite) { c.RemoveEventHandlers() }},
+		{"Normalize", func(c *CopyOnWrite) { c.Normalize() }},
+		{"NormalizeVisibility", func(c *CopyOnWrite) { c.NormalizeVisibility(HiddenWins) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCopyOnWrite(shared)
+			tt.mutate(c)
+			if c.IsCopied() {
+				t.Error("a change that did nothing made a copy")
+			}
+			if shared.String() != want {
+				t.Errorf("the shared attributes were changed to %s", shared)
+			}
+		})
+	}
+}
+
+func TestCopyOnWrite_Read(t *testing.T) {
+	shared := Attributes{"id": "a", "class": "b", "style": "color:red", "data-my-val": "1", "disabled": ""}
+	c := NewCopyOnWrite(shared)
+	if !c.HasAttributeValue("class", "b") || !c.HasClassWithPrefix("b") || !c.HasDataAttribute("myVal") ||
+		!c.HasStyle("color") || !c.IsDisabled() || !c.IsDisplayed() || !c.IsBooleanTrue("disabled") {
+		t.Error("expected to read the shared attributes")
+	}
+	if c.SortedString() != shared.String() || c.StyleString() != "color:red" || c.StyleMap().Get("color") != "red" ||
+		c.Selector("div") != shared.Selector("div") || c.DebugString() != shared.DebugString() {
+		t.Error("expected the same output as the shared attributes")
+	}
+	b := strings.Builder{}
+	if _, err := c.WriteSortedTo(&b); err != nil || b.String() != shared.String() {
+		t.Errorf("WriteSortedTo() = %s, %v", b.String(), err)
+	}
+	cp := c.Copy()
+	cp.SetID("z")
+	if c.ID() != "a" || c.Freeze().ID() != "a" || c.Resolve(context.Background()).ID() != "a" {
+		t.Error("expected copies to be independent")
+	}
+	if c.IsCopied() {
+		t.Error("reading should not copy")
+	}
+}
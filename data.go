@@ -28,6 +28,15 @@ func ToDataAttr(s string) (string, error) {
 	return strings.TrimSpace(strings.TrimPrefix(s, "-")), err
 }
 
+// ToDataAttrStrict is like ToDataAttr, but also rejects names that begin with "xml", which
+// html reserves and does not allow at the start of the name of a data-* attribute.
+func ToDataAttrStrict(s string) (string, error) {
+	if strings.HasPrefix(strings.ToLower(s), "xml") {
+		return s, fmt.Errorf("%s cannot start with xml", s)
+	}
+	return ToDataAttr(s)
+}
+
 // ToDataKey is a helper function to convert a name from kabob-case to camelCase.
 //
 // data-* html attributes have special conversion rules. Key names should always be lower case. Dashes in the
@@ -44,6 +53,10 @@ func ToDataKey(s string) (string, error) {
 	pieces := strings.Split(s, "-")
 	var ret string
 	for i, p := range pieces {
+		if p == "" && s != "" {
+			err := errors.New("kabob names cannot have leading, trailing or double dashes")
+			return s, err
+		}
 		if len(p) == 1 {
 			err := errors.New("individual kabob words must be at least 2 characters long")
 			return s, err
@@ -55,3 +68,26 @@ func ToDataKey(s string) (string, error) {
 	}
 	return ret, nil
 }
+
+// DataAttrName returns the full name of the data-* attribute that holds the value of the given camelCase key.
+// For example, "testVar" becomes "data-test-var".
+func DataAttrName(camelCase string) (string, error) {
+	if camelCase == "" {
+		return "", errors.New("a data attribute key cannot be empty")
+	}
+	s, err := ToDataAttr(camelCase)
+	if err != nil {
+		return "", err
+	}
+	return "data-" + s, nil
+}
+
+// DataKeyName returns the camelCase key that javascript uses in the dataset of an element to get the value of
+// the given data-* attribute. For example, "data-test-var" becomes "testVar".
+func DataKeyName(kebab string) (string, error) {
+	s := strings.TrimPrefix(kebab, "data-")
+	if s == kebab || s == "" {
+		return "", fmt.Errorf("%s is not the name of a data attribute", kebab)
+	}
+	return ToDataKey(s)
+}
@@ -12,13 +12,22 @@ func ExampleToDataAttr() {
 
 }
 
-func ExampleToDataJqKey() {
+func ExampleToDataKey() {
 	s, _ := ToDataKey("this-is-my-test")
 	fmt.Println(s)
 	// Output: thisIsMyTest
 
 }
 
+func ExampleDataAttrName() {
+	s, _ := DataAttrName("thisIsMyTest")
+	fmt.Println(s)
+	s, _ = DataKeyName(s)
+	fmt.Println(s)
+	// Output: data-this-is-my-test
+	// thisIsMyTest
+}
+
 func TestToDataAttr(t *testing.T) {
 
 	cases := []struct {
@@ -71,6 +80,9 @@ func TestToDataKey(t *testing.T) {
 		{"this-and-that", "thisAndThat", false},
 		{"this and that", "", true},
 		{"a-b-c", "", true},
+		{"this--that", "", true},
+		{"-this", "", true},
+		{"this-", "", true},
 	}
 
 	for _, c := range cases {
@@ -95,3 +107,35 @@ func TestToDataKey(t *testing.T) {
 	}
 
 }
+
+func TestToDataAttrStrict(t *testing.T) {
+	if _, err := ToDataAttrStrict("xmlThing"); err == nil {
+		t.Error("expected error on xml prefix")
+	}
+	if _, err := ToDataAttrStrict("XmlThing"); err == nil {
+		t.Error("expected error on xml prefix")
+	}
+	if s, err := ToDataAttrStrict("myXml"); err != nil || s != "my-xml" {
+		t.Errorf("ToDataAttrStrict() = %q, %v", s, err)
+	}
+	if s, err := ToDataAttr("xmlThing"); err != nil || s != "xml-thing" {
+		t.Errorf("ToDataAttr() should not reject xml, got %q, %v", s, err)
+	}
+}
+
+func TestDataAttrName(t *testing.T) {
+	if _, err := DataAttrName(""); err == nil {
+		t.Error("expected error on an empty key")
+	}
+	if _, err := DataAttrName("ThisThat"); err == nil {
+		t.Error("expected error on an invalid key")
+	}
+	for _, in := range []string{"", "data-", "test-var", "data-Test"} {
+		if _, err := DataKeyName(in); err == nil {
+			t.Errorf("DataKeyName(%q) expected an error", in)
+		}
+	}
+	if s, err := DataKeyName("data-ab"); err != nil || s != "ab" {
+		t.Errorf("DataKeyName() = %q, %v", s, err)
+	}
+}
@@ -0,0 +1,36 @@
+package html5tag
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DebugSourceAttribute is the name of the attribute added when the DebugSource option of a Renderer is on.
+const DebugSourceAttribute = "data-html5tag-source"
+
+// packageDir is the directory of the source files of this package, used to skip our own frames
+// when looking for the caller.
+var packageDir string
+
+func init() {
+	_, file, _, _ := runtime.Caller(0)
+	packageDir = filepath.Dir(file)
+}
+
+// callerSource returns the "file:line" of the first caller outside of this package.
+func callerSource() string {
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		f, more := frames.Next()
+		if f.File != "" && (filepath.Dir(f.File) != packageDir || strings.HasSuffix(f.File, "_test.go")) {
+			return fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package html5tag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDebugSource(t *testing.T) {
+	r := &Renderer{DebugSource: true}
+
+	a := Attributes{"id": "a"}
+	s := r.RenderTag("div", a, r.RenderVoidTag("br", nil))
+	if c := strings.Count(s, DebugSourceAttribute+`="`); c != 2 {
+		t.Errorf("expected 2 source attributes, got %d in %s", c, s)
+	}
+	if !strings.Contains(s, "debug_test.go:") {
+		t.Errorf("expected the test file as the source, got %s", s)
+	}
+	if a.Has(DebugSourceAttribute) {
+		t.Error("the given attributes should not be changed")
+	}
+
+	b := strings.Builder{}
+	st := NewTagStack(&b).SetRenderer(r)
+	_ = st.Open("div", a)
+	_ = st.CloseAll()
+	if !strings.Contains(b.String(), DebugSourceAttribute+`="`) || !strings.Contains(b.String(), "debug_test.go:") {
+		t.Errorf("expected TagStack to add the source attribute, got %s", b.String())
+	}
+	if a.Has(DebugSourceAttribute) {
+		t.Error("TagStack should not change the given attributes")
+	}
+
+	r.Hooks = []RenderHook{func(tag string, attr Attributes) Attributes {
+		attr.Remove(DebugSourceAttribute)
+		return attr
+	}}
+	if s = r.RenderVoidTag("br", nil); s != "<br>" {
+		t.Errorf("expected a hook to remove the source, got %s", s)
+	}
+}
+
+func TestDebugSourceOff(t *testing.T) {
+	if s := RenderVoidTag("br", nil); s != "<br>" {
+		t.Errorf("expected no source attribute, got %s", s)
+	}
+	var r *Renderer
+	if s := r.RenderVoidTag("br", nil); s != "<br>" {
+		t.Errorf("expected no source attribute from a nil Renderer, got %s", s)
+	}
+}
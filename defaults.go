@@ -0,0 +1,52 @@
+package html5tag
+
+import "strings"
+
+// defaultAttributeValues lists, for each tag, the attributes whose values are the defaults for that tag.
+var defaultAttributeValues = map[string]map[string]string{
+	"area":     {"shape": "rect"},
+	"button":   {"type": "submit"},
+	"canvas":   {"width": "300", "height": "150"},
+	"form":     {"method": "get", "enctype": "application/x-www-form-urlencoded", "autocomplete": "on"},
+	"iframe":   {"loading": "eager"},
+	"img":      {"loading": "eager", "decoding": "auto"},
+	"input":    {"type": "text"},
+	"ol":       {"start": "1", "type": "1"},
+	"script":   {"type": "text/javascript"},
+	"style":    {"media": "all"},
+	"td":       {"colspan": "1", "rowspan": "1"},
+	"th":       {"colspan": "1", "rowspan": "1"},
+	"textarea": {"wrap": "soft"},
+	"track":    {"kind": "subtitles"},
+}
+
+// defaultsChangedBy lists, for each tag, the attributes whose default value is different when another
+// attribute is present. For example, the default start of a reversed list is the number of items, not 1.
+var defaultsChangedBy = map[string]map[string]string{
+	"ol": {"start": "reversed"},
+}
+
+// omitDefaults returns attr without the attributes that have their default values for the tag.
+// attr is not changed.
+func omitDefaults(tag string, attr Attributes) Attributes {
+	defaults, ok := defaultAttributeValues[tag]
+	if !ok {
+		return attr
+	}
+	var out Attributes
+	for k, d := range defaults {
+		if other, ok := defaultsChangedBy[tag][k]; ok && attr.Has(other) && !attr.IsBooleanFalse(other) {
+			continue
+		}
+		if v, has := attr[k]; has && strings.EqualFold(strings.TrimSpace(v), d) {
+			if out == nil {
+				out = attr.Copy()
+			}
+			delete(out, k)
+		}
+	}
+	if out == nil {
+		return attr
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package html5tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleRenderer_omitDefaults() {
+	r := &Renderer{OmitDefaults: true}
+	fmt.Println(r.RenderVoidTag("input", Attributes{"type": "text", "name": "a"}))
+	fmt.Println(r.RenderVoidTag("input", Attributes{"type": "checkbox", "name": "b"}))
+	// Output: <input name="a">
+	// <input name="b" type="checkbox">
+}
+
+func TestOmitDefaults(t *testing.T) {
+	r := &Renderer{OmitDefaults: true}
+
+	tests := []struct {
+		name string
+		tag  string
+		attr Attributes
+		want string
+	}{
+		{"form", "form", Attributes{"method": "GET", "action": "/a"}, `<form action="/a"></form>`},
+		{"form post", "form", Attributes{"method": "post"}, `<form method="post"></form>`},
+		{"button", "button", Attributes{"type": "submit"}, `<button></button>`},
+		{"ol", "ol", Attributes{"start": "1", "type": "1"}, `<ol></ol>`},
+		{"reversed ol keeps start", "ol", Attributes{"start": "1", "reversed": ""}, `<ol reversed start="1"></ol>`},
+		{"td", "td", Attributes{"colspan": "1", "rowspan": "2"}, `<td rowspan="2"></td>`},
+		{"link rel is kept", "link", Attributes{"rel": "stylesheet"}, `<link rel="stylesheet">`},
+		{"other tags are kept", "div", Attributes{"type": "text"}, `<div type="text"></div>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if voidTags[tt.tag] {
+				got = r.RenderVoidTag(tt.tag, tt.attr)
+			} else {
+				got = r.RenderTagNoSpace(tt.tag, tt.attr, "")
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	a := Attributes{"type": "text"}
+	r.RenderVoidTag("input", a)
+	if !a.Has("type") {
+		t.Error("OmitDefaults changed the attributes being rendered")
+	}
+}
@@ -0,0 +1,74 @@
+package html5tag
+
+import (
+	"fmt"
+)
+
+var contentEditableValues = map[string]bool{
+	"":               true,
+	"true":           true,
+	"false":          true,
+	"plaintext-only": true,
+}
+
+var autocapitalizeValues = map[string]bool{
+	"off":        true,
+	"none":       true,
+	"on":         true,
+	"sentences":  true,
+	"words":      true,
+	"characters": true,
+}
+
+// SetContentEditableChanged sets the contenteditable attribute to the given mode, and returns true if something changed.
+// mode must be one of "true", "false", "plaintext-only", or an empty string, which means "true".
+//
+// Note that "false" is written out literally, since contenteditable is an enumerated attribute and not a boolean one.
+// Setting it to "false" turns off editing that would otherwise be inherited from a parent.
+func (a Attributes) SetContentEditableChanged(mode string) (changed bool, err error) {
+	if !contentEditableValues[mode] {
+		err = fmt.Errorf("%s is not a valid contenteditable value", mode)
+		return
+	}
+	changed = a.set("contenteditable", mode)
+	return
+}
+
+// SetContentEditable sets the contenteditable attribute to the given mode. It panics if mode is not valid.
+// See SetContentEditableChanged.
+func (a Attributes) SetContentEditable(mode string) Attributes {
+	if _, err := a.SetContentEditableChanged(mode); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// SetSpellcheck sets the spellcheck attribute to "true" or "false". Like contenteditable, spellcheck is an
+// enumerated attribute, so a value of "false" is written out rather than removing the attribute.
+func (a Attributes) SetSpellcheck(check bool) Attributes {
+	if check {
+		a.set("spellcheck", "true")
+	} else {
+		a.set("spellcheck", "false")
+	}
+	return a
+}
+
+// SetAutocapitalizeChanged sets the autocapitalize attribute to the given token, and returns true if something changed.
+// token must be one of "off", "none", "on", "sentences", "words" or "characters".
+func (a Attributes) SetAutocapitalizeChanged(token string) (changed bool, err error) {
+	if !autocapitalizeValues[token] {
+		err = fmt.Errorf("%s is not a valid autocapitalize value", token)
+		return
+	}
+	changed = a.set("autocapitalize", token)
+	return
+}
+
+// SetAutocapitalize sets the autocapitalize attribute to the given token. It panics if the token is not valid.
+func (a Attributes) SetAutocapitalize(token string) Attributes {
+	if _, err := a.SetAutocapitalizeChanged(token); err != nil {
+		panic(err)
+	}
+	return a
+}
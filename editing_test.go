@@ -0,0 +1,46 @@
+package html5tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleAttributes_SetContentEditable() {
+	a := NewAttributes().SetContentEditable("false")
+	fmt.Println(a.String())
+	a.SetContentEditable("plaintext-only")
+	fmt.Println(a.String())
+	// Output: contenteditable="false"
+	// contenteditable="plaintext-only"
+}
+
+func ExampleAttributes_SetSpellcheck() {
+	a := NewAttributes().SetSpellcheck(false)
+	fmt.Println(a.String())
+	// Output: spellcheck="false"
+}
+
+func TestEditingAttributes(t *testing.T) {
+	a := NewAttributes()
+	if _, err := a.SetContentEditableChanged("yes"); err == nil {
+		t.Error("expected error on invalid contenteditable value")
+	}
+	if changed, err := a.SetContentEditableChanged(""); !changed || err != nil || a.String() != "contenteditable" {
+		t.Errorf("SetContentEditableChanged() = %v, %v, %q", changed, err, a.String())
+	}
+	if changed, _ := a.SetContentEditableChanged(""); changed {
+		t.Error("expected no change")
+	}
+
+	a = NewAttributes()
+	if _, err := a.SetAutocapitalizeChanged("Words"); err == nil {
+		t.Error("expected error on invalid autocapitalize value")
+	}
+	a.SetAutocapitalize("words")
+	if a.String() != `autocapitalize="words"` {
+		t.Errorf("SetAutocapitalize() = %q", a.String())
+	}
+	if NewAttributes().SetSpellcheck(true).String() != `spellcheck="true"` {
+		t.Error("SetSpellcheck(true) failed")
+	}
+}
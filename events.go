@@ -0,0 +1,165 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// domEvents is the list of DOM events that can be assigned an inline handler through an on* attribute.
+var domEvents = map[string]bool{
+	"abort":                   true,
+	"afterprint":              true,
+	"animationend":            true,
+	"animationiteration":      true,
+	"animationstart":          true,
+	"auxclick":                true,
+	"beforeinput":             true,
+	"beforeprint":             true,
+	"beforeunload":            true,
+	"blur":                    true,
+	"cancel":                  true,
+	"canplay":                 true,
+	"canplaythrough":          true,
+	"change":                  true,
+	"click":                   true,
+	"close":                   true,
+	"contextmenu":             true,
+	"copy":                    true,
+	"cuechange":               true,
+	"cut":                     true,
+	"dblclick":                true,
+	"drag":                    true,
+	"dragend":                 true,
+	"dragenter":               true,
+	"dragleave":               true,
+	"dragover":                true,
+	"dragstart":               true,
+	"drop":                    true,
+	"durationchange":          true,
+	"emptied":                 true,
+	"ended":                   true,
+	"error":                   true,
+	"focus":                   true,
+	"focusin":                 true,
+	"focusout":                true,
+	"formdata":                true,
+	"hashchange":              true,
+	"input":                   true,
+	"invalid":                 true,
+	"keydown":                 true,
+	"keypress":                true,
+	"keyup":                   true,
+	"languagechange":          true,
+	"load":                    true,
+	"loadeddata":              true,
+	"loadedmetadata":          true,
+	"loadstart":               true,
+	"message":                 true,
+	"messageerror":            true,
+	"mousedown":               true,
+	"mouseenter":              true,
+	"mouseleave":              true,
+	"mousemove":               true,
+	"mouseout":                true,
+	"mouseover":               true,
+	"mouseup":                 true,
+	"offline":                 true,
+	"online":                  true,
+	"pagehide":                true,
+	"pageshow":                true,
+	"paste":                   true,
+	"pause":                   true,
+	"play":                    true,
+	"playing":                 true,
+	"pointercancel":           true,
+	"pointerdown":             true,
+	"pointerenter":            true,
+	"pointerleave":            true,
+	"pointermove":             true,
+	"pointerout":              true,
+	"pointerover":             true,
+	"pointerup":               true,
+	"popstate":                true,
+	"progress":                true,
+	"ratechange":              true,
+	"reset":                   true,
+	"resize":                  true,
+	"scroll":                  true,
+	"scrollend":               true,
+	"securitypolicyviolation": true,
+	"seeked":                  true,
+	"seeking":                 true,
+	"select":                  true,
+	"selectionchange":         true,
+	"selectstart":             true,
+	"slotchange":              true,
+	"stalled":                 true,
+	"storage":                 true,
+	"submit":                  true,
+	"suspend":                 true,
+	"timeupdate":              true,
+	"toggle":                  true,
+	"touchcancel":             true,
+	"touchend":                true,
+	"touchmove":               true,
+	"touchstart":              true,
+	"transitioncancel":        true,
+	"transitionend":           true,
+	"transitionrun":           true,
+	"transitionstart":         true,
+	"unhandledrejection":      true,
+	"unload":                  true,
+	"volumechange":            true,
+	"waiting":                 true,
+	"wheel":                   true,
+}
+
+// IsEventAttribute returns true if name is the name of an inline event handler attribute, like "onclick".
+func IsEventAttribute(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasPrefix(name, "on") && domEvents[name[2:]]
+}
+
+// SetEventHandlerChanged sets the inline javascript handler for the given DOM event, and returns true if
+// something changed. The event is given without the "on" prefix, so to set the onclick attribute, pass "click".
+// Returns an error if the event is not a known DOM event.
+//
+// Setting js to an empty string removes the handler.
+func (a Attributes) SetEventHandlerChanged(event string, js string) (changed bool, err error) {
+	event = strings.ToLower(event)
+	if !domEvents[event] {
+		err = fmt.Errorf("%s is not a known DOM event", event)
+		return
+	}
+	if js == "" {
+		changed = a.RemoveAttribute("on" + event)
+		return
+	}
+	changed = a.set("on"+event, js)
+	return
+}
+
+// SetEventHandler sets the inline javascript handler for the given DOM event, and returns the
+// attributes so that calls can be chained. It panics if the event is not a known DOM event.
+//
+//	a.SetEventHandler("click", "doSomething()")
+func (a Attributes) SetEventHandler(event string, js string) Attributes {
+	_, err := a.SetEventHandlerChanged(event, js)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// RemoveEventHandlers removes all the inline event handler attributes, like "onclick". Attributes that start
+// with "on" but are not DOM events are left alone.
+// Returns true if something was removed.
+func (a Attributes) RemoveEventHandlers() (changed bool) {
+	for k := range a {
+		if IsEventAttribute(k) {
+			delete(a, k)
+			changed = true
+		}
+	}
+	return
+}
@@ -0,0 +1,35 @@
+package html5tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleAttributes_SetEventHandler() {
+	a := NewAttributes().SetEventHandler("click", "doIt()")
+	fmt.Println(a.String())
+	// Output: onclick="doIt()"
+}
+
+func ExampleAttributes_RemoveEventHandlers() {
+	a := Attributes{"onclick": "a()", "onkeyup": "b()", "one": "c", "id": "d"}
+	a.RemoveEventHandlers()
+	fmt.Println(a.SortedString())
+	// Output: id="d" one="c"
+}
+
+func TestAttributes_SetEventHandlerChanged(t *testing.T) {
+	a := NewAttributes()
+	if changed, err := a.SetEventHandlerChanged("Click", "a()"); !changed || err != nil {
+		t.Errorf("SetEventHandlerChanged() = %v, %v", changed, err)
+	}
+	if changed, _ := a.SetEventHandlerChanged("click", "a()"); changed {
+		t.Error("expected no change")
+	}
+	if _, err := a.SetEventHandlerChanged("clack", "a()"); err == nil {
+		t.Error("expected an error on an unknown event")
+	}
+	if changed, _ := a.SetEventHandlerChanged("click", ""); !changed || a.Has("onclick") {
+		t.Error("expected handler to be removed")
+	}
+}
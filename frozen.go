@@ -0,0 +1,154 @@
+package html5tag
+
+import "io"
+
+// FrozenAttributes is a read-only set of attributes. It has the methods of Attributes that read and render
+// the attributes, but none that change them. Use it for attributes that are shared or cached and must not change.
+//
+// Use the RenderTag and WriteTag methods to render a tag with the attributes without copying them,
+// and call Thaw to get a copy that can be changed.
+type FrozenAttributes struct {
+	a Attributes
+}
+
+// Freeze returns a read-only copy of the attributes. Later changes to a do not affect the frozen copy.
+func (a Attributes) Freeze() FrozenAttributes {
+	return FrozenAttributes{a.Copy()}
+}
+
+// Thaw returns a copy of the attributes that can be changed.
+func (f FrozenAttributes) Thaw() Attributes {
+	return f.a.Copy()
+}
+
+// Len returns the number of attributes.
+func (f FrozenAttributes) Len() int {
+	return f.a.Len()
+}
+
+// Has returns true if the named attribute is present.
+func (f FrozenAttributes) Has(attr string) bool {
+	return f.a.Has(attr)
+}
+
+// Get returns the named attribute.
+func (f FrozenAttributes) Get(attr string) string {
+	return f.a.Get(attr)
+}
+
+// ID returns the value of the id attribute.
+func (f FrozenAttributes) ID() string {
+	return f.a.ID()
+}
+
+// Class returns the value of the class attribute.
+func (f FrozenAttributes) Class() string {
+	return f.a.Class()
+}
+
+// HasClass returns true if the class attribute contains the given class.
+func (f FrozenAttributes) HasClass(c string) bool {
+	return f.a.HasClass(c)
+}
+
+// HasAttributeValue returns true if the named attribute contains the given space separated value.
+func (f FrozenAttributes) HasAttributeValue(attr string, value string) bool {
+	return f.a.HasAttributeValue(attr, value)
+}
+
+// DataAttribute returns the value of the data attribute with the given camelCase key.
+func (f FrozenAttributes) DataAttribute(key string) string {
+	return f.a.DataAttribute(key)
+}
+
+// HasDataAttribute returns true if the data attribute with the given camelCase key is present.
+func (f FrozenAttributes) HasDataAttribute(key string) bool {
+	return f.a.HasDataAttribute(key)
+}
+
+// StyleString returns the value of the style attribute.
+func (f FrozenAttributes) StyleString() string {
+	return f.a.StyleString()
+}
+
+// StyleMap returns a copy of the styles.
+func (f FrozenAttributes) StyleMap() Style {
+	return f.a.StyleMap()
+}
+
+// GetStyle returns the value of the given style property.
+func (f FrozenAttributes) GetStyle(name string) string {
+	return f.a.GetStyle(name)
+}
+
+// HasStyle returns true if the given style property is set.
+func (f FrozenAttributes) HasStyle(name string) bool {
+	return f.a.HasStyle(name)
+}
+
+// IsDisabled returns true if the disabled attribute is set.
+func (f FrozenAttributes) IsDisabled() bool {
+	return f.a.IsDisabled()
+}
+
+// IsDisplayed returns true if the display style is not set to none.
+func (f FrozenAttributes) IsDisplayed() bool {
+	return f.a.IsDisplayed()
+}
+
+// Range calls fn for each attribute, in the same order as Attributes.Range.
+func (f FrozenAttributes) Range(fn func(key string, value string) bool) {
+	f.a.Range(fn)
+}
+
+// String returns the attributes escaped and encoded, ready to be placed in an HTML tag.
+func (f FrozenAttributes) String() string {
+	return f.a.String()
+}
+
+// SortedString returns the attributes escaped and encoded, with sorted keys.
+func (f FrozenAttributes) SortedString() string {
+	return f.a.SortedString()
+}
+
+// WriteTo writes the attributes escaped and encoded.
+func (f FrozenAttributes) WriteTo(w io.Writer) (n int64, err error) {
+	return f.a.WriteTo(w)
+}
+
+// WriteSortedTo writes the attributes escaped, encoded and with sorted keys.
+func (f FrozenAttributes) WriteSortedTo(w io.Writer) (n int64, err error) {
+	return f.a.WriteSortedTo(w)
+}
+
+// RenderTag renders a tag with the frozen attributes, without copying them. See the RenderTag function.
+func (f FrozenAttributes) RenderTag(tag string, innerHtml string) string {
+	return RenderTag(tag, f.a, innerHtml)
+}
+
+// RenderTagNoSpace renders a tag with the frozen attributes, without copying them. See the RenderTagNoSpace function.
+func (f FrozenAttributes) RenderTagNoSpace(tag string, innerHtml string) string {
+	return RenderTagNoSpace(tag, f.a, innerHtml)
+}
+
+// RenderVoidTag renders a void tag with the frozen attributes, without copying them. See the RenderVoidTag function.
+func (f FrozenAttributes) RenderVoidTag(tag string) string {
+	return RenderVoidTag(tag, f.a)
+}
+
+// WriteTag writes a tag with the frozen attributes to w, without copying them. See the WriteTag function.
+func (f FrozenAttributes) WriteTag(w io.Writer, tag string, innerHtml io.WriterTo) (n int, err error) {
+	return WriteTag(w, tag, f.a, innerHtml)
+}
+
+// WriteTagNoSpace writes a tag with the frozen attributes to w, without copying them.
+// See the WriteTagNoSpace function.
+func (f FrozenAttributes) WriteTagNoSpace(w io.Writer, tag string, innerHtml io.WriterTo) (n int, err error) {
+	return WriteTagNoSpace(w, tag, f.a, innerHtml)
+}
+
+// WriteVoidTag writes a void tag with the frozen attributes to w, without copying them.
+// See the WriteVoidTag function.
+func (f FrozenAttributes) WriteVoidTag(w io.Writer, tag string) (n int, err error) {
+	return WriteVoidTag(w, tag, f.a)
+}
@@ -0,0 +1,104 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleAttributes_Freeze() {
+	a := Attributes{"class": "btn", "id": "save"}
+	f := a.Freeze()
+	a.AddClass("big")
+
+	fmt.Println(f.Class())
+	t := f.Thaw().AddClass("primary")
+	fmt.Println(t.Class(), f.Class())
+	// Output: btn
+	// btn primary btn
+}
+
+func TestFrozenAttributes(t *testing.T) {
+	a := Attributes{"id": "a", "class": "b c", "style": "color:red;display:none", "data-my-val": "1", "disabled": ""}
+	f := a.Freeze()
+
+	if f.Len() != 5 || !f.Has("id") || f.Get("id") != "a" || f.ID() != "a" {
+		t.Error("attribute getters failed")
+	}
+	if !f.HasClass("c") || f.Class() != "b c" || !f.HasAttributeValue("class", "b") {
+		t.Error("class getters failed")
+	}
+	if f.DataAttribute("myVal") != "1" || !f.HasDataAttribute("myVal") {
+		t.Error("data getters failed")
+	}
+	if f.GetStyle("color") != "red" || !f.HasStyle("display") || f.StyleString() != "color:red;display:none" {
+		t.Error("style getters failed")
+	}
+	f.StyleMap().Set("color", "blue")
+	if f.GetStyle("color") != "red" {
+		t.Error("changing the style map changed the frozen attributes")
+	}
+	if !f.IsDisabled() || f.IsDisplayed() {
+		t.Error("state getters failed")
+	}
+	if f.String() != a.String() || f.SortedString() != a.SortedString() {
+		t.Error("string output does not match")
+	}
+	b := strings.Builder{}
+	if _, err := f.WriteTo(&b); err != nil || b.String() != a.String() {
+		t.Errorf("WriteTo() = %s, %v", b.String(), err)
+	}
+	b.Reset()
+	if _, err := f.WriteSortedTo(&b); err != nil || b.String() != a.SortedString() {
+		t.Errorf("WriteSortedTo() = %s, %v", b.String(), err)
+	}
+	var count int
+	f.Range(func(k, v string) bool {
+		count++
+		return true
+	})
+	if count != 5 {
+		t.Errorf("Range() visited %d attributes", count)
+	}
+}
+
+func ExampleFrozenAttributes_RenderTagNoSpace() {
+	f := Attributes{"class": "btn", "type": "button"}.Freeze()
+	fmt.Println(f.RenderTagNoSpace("button", "Save"))
+	fmt.Println(f.RenderVoidTag("input"))
+	// Output: <button class="btn" type="button">Save</button>
+	// <input class="btn" type="button">
+}
+
+func TestFrozenAttributes_Render(t *testing.T) {
+	a := Attributes{"id": "a", "type": "text"}
+	f := a.Freeze()
+	if got, want := f.RenderTag("div", "x"), RenderTag("div", a, "x"); got != want {
+		t.Errorf("RenderTag() = %s, want %s", got, want)
+	}
+	b := strings.Builder{}
+	if _, err := f.WriteTag(&b, "div", strings.NewReader("x")); err != nil || b.String() != RenderTag("div", a, "x") {
+		t.Errorf("WriteTag() = %s, %v", b.String(), err)
+	}
+	b.Reset()
+	if _, err := f.WriteTagNoSpace(&b, "div", strings.NewReader("x")); err != nil || b.String() != RenderTagNoSpace("div", a, "x") {
+		t.Errorf("WriteTagNoSpace() = %s, %v", b.String(), err)
+	}
+	b.Reset()
+	if _, err := f.WriteVoidTag(&b, "input"); err != nil || b.String() != RenderVoidTag("input", a) {
+		t.Errorf("WriteVoidTag() = %s, %v", b.String(), err)
+	}
+
+	// hooks get a copy, so they cannot change the frozen attributes
+	r := &Renderer{
+		Hooks: []RenderHook{func(tag string, attr Attributes) Attributes {
+			attr.Set("id", "changed")
+			return attr
+		}},
+		OmitDefaults: true,
+	}
+	r.RenderVoidTag("input", f.a)
+	if f.Get("id") != "a" || f.Get("type") != "text" {
+		t.Errorf("rendering changed the frozen attributes: %v", f)
+	}
+}
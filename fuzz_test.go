@@ -0,0 +1,33 @@
+//go:build go1.18
+// +build go1.18
+
+package html5tag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func FuzzAttributesRoundTrip(f *testing.F) {
+	f.Add(`id="a" class="b c" style="color:red; width: 4px"`)
+	f.Add(`href="/search?q=1&amp;p=2" title='say "hi"'`)
+	f.Add(`disabled value="" data-my-val=1 xlink:href="#a"`)
+	f.Add(`content="&lt;&#39;&#34;&gt;" style="background:url(a;b)"`)
+	f.Fuzz(func(t *testing.T, s string) {
+		a, err := ParseAttributes(s)
+		if err != nil {
+			return // not valid attributes
+		}
+		s2 := a.SortedString()
+		a2, err := ParseAttributes(s2)
+		if err != nil {
+			t.Fatalf("ParseAttributes(%q) of rendered %q: %v", s, s2, err)
+		}
+		if a.Len() == 0 && a2.Len() == 0 {
+			return
+		}
+		if !reflect.DeepEqual(a, a2) {
+			t.Fatalf("round trip of %q through %q: got %#v, want %#v", s, s2, a2, a)
+		}
+	})
+}
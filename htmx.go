@@ -0,0 +1,146 @@
+package html5tag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// hxSwapModes are the values htmx accepts as the first word of an hx-swap attribute.
+var hxSwapModes = map[string]bool{
+	"innerHTML":   true,
+	"outerHTML":   true,
+	"textContent": true,
+	"beforebegin": true,
+	"afterbegin":  true,
+	"beforeend":   true,
+	"afterend":    true,
+	"delete":      true,
+	"none":        true,
+}
+
+// hxSwapModifiers are the modifiers that can follow the swap mode in an hx-swap attribute.
+var hxSwapModifiers = map[string]bool{
+	"transition":   true,
+	"swap":         true,
+	"settle":       true,
+	"ignoreTitle":  true,
+	"scroll":       true,
+	"show":         true,
+	"focus-scroll": true,
+}
+
+// SetHxGetChanged sets the hx-get attribute, which makes htmx issue a GET request to url,
+// and returns true if something changed.
+// url is checked with NormalizeURL, and must be relative or use the http or https scheme.
+func (a Attributes) SetHxGetChanged(url string) (changed bool, err error) {
+	return a.setHxURL("hx-get", url)
+}
+
+// SetHxGet sets the hx-get attribute. It panics if url is not valid. See SetHxGetChanged.
+func (a Attributes) SetHxGet(url string) Attributes {
+	if _, err := a.SetHxGetChanged(url); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// SetHxPostChanged sets the hx-post attribute, which makes htmx issue a POST request to url,
+// and returns true if something changed.
+// url is checked with NormalizeURL, and must be relative or use the http or https scheme.
+func (a Attributes) SetHxPostChanged(url string) (changed bool, err error) {
+	return a.setHxURL("hx-post", url)
+}
+
+// SetHxPost sets the hx-post attribute. It panics if url is not valid. See SetHxPostChanged.
+func (a Attributes) SetHxPost(url string) Attributes {
+	if _, err := a.SetHxPostChanged(url); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (a Attributes) setHxURL(name, url string) (changed bool, err error) {
+	u, err := NormalizeURL(url)
+	if err != nil {
+		return
+	}
+	if m := urlSchemeMatcher.FindStringSubmatch(u); m != nil {
+		if scheme := strings.ToLower(m[1]); scheme != "http" && scheme != "https" {
+			err = fmt.Errorf("url scheme %q is not allowed in %s", scheme, name)
+			return
+		}
+	}
+	changed = a.set(name, u)
+	return
+}
+
+// SetHxTriggerChanged sets the hx-trigger attribute, which gives the events that start a request,
+// like "click" or "keyup changed delay:500ms", and returns true if something changed.
+// The spec is not checked, other than that it cannot be empty.
+func (a Attributes) SetHxTriggerChanged(spec string) (changed bool, err error) {
+	if strings.TrimSpace(spec) == "" {
+		err = errors.New("hx-trigger cannot be empty")
+		return
+	}
+	changed = a.set("hx-trigger", spec)
+	return
+}
+
+// SetHxTrigger sets the hx-trigger attribute. It panics if spec is empty. See SetHxTriggerChanged.
+func (a Attributes) SetHxTrigger(spec string) Attributes {
+	if _, err := a.SetHxTriggerChanged(spec); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// SetHxTargetChanged sets the hx-target attribute, which is the css selector of the element that the response
+// is swapped into, and returns true if something changed. The selector cannot be empty.
+func (a Attributes) SetHxTargetChanged(selector string) (changed bool, err error) {
+	if strings.TrimSpace(selector) == "" {
+		err = errors.New("hx-target cannot be empty")
+		return
+	}
+	changed = a.set("hx-target", selector)
+	return
+}
+
+// SetHxTarget sets the hx-target attribute. It panics if selector is empty. See SetHxTargetChanged.
+func (a Attributes) SetHxTarget(selector string) Attributes {
+	if _, err := a.SetHxTargetChanged(selector); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// SetHxSwapChanged sets the hx-swap attribute, which tells htmx how to swap the response into the target,
+// and returns true if something changed.
+//
+// mode must start with one of "innerHTML", "outerHTML", "textContent", "beforebegin", "afterbegin",
+// "beforeend", "afterend", "delete" or "none", which can be followed by modifiers like "swap:1s" or "scroll:top".
+// Swap modes are case-sensitive.
+func (a Attributes) SetHxSwapChanged(mode string) (changed bool, err error) {
+	fields := strings.Fields(mode)
+	if len(fields) == 0 || !hxSwapModes[fields[0]] {
+		err = fmt.Errorf("%q is not a valid hx-swap mode", mode)
+		return
+	}
+	for _, f := range fields[1:] {
+		name := strings.SplitN(f, ":", 2)[0]
+		if !hxSwapModifiers[name] || !strings.Contains(f, ":") {
+			err = fmt.Errorf("%q is not a valid hx-swap modifier", f)
+			return
+		}
+	}
+	changed = a.set("hx-swap", strings.Join(fields, " "))
+	return
+}
+
+// SetHxSwap sets the hx-swap attribute. It panics if mode is not valid. See SetHxSwapChanged.
+func (a Attributes) SetHxSwap(mode string) Attributes {
+	if _, err := a.SetHxSwapChanged(mode); err != nil {
+		panic(err)
+	}
+	return a
+}
@@ -0,0 +1,82 @@
+package html5tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleAttributes_SetHxGet() {
+	a := NewAttributes().SetHxGet("/contacts").SetHxTarget("#list").SetHxSwap("outerHTML").SetHxTrigger("load")
+	fmt.Println(a.SortedString())
+	// Output: hx-get="/contacts" hx-swap="outerHTML" hx-target="#list" hx-trigger="load"
+}
+
+func TestHxURLs(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"/contacts", true},
+		{"https://example.com/a", true},
+		{" http://example.com/a ", true},
+		{"javascript:alert(1)", false},
+		{"mailto:a@example.com", false},
+		{"data:image/png;base64,AAAA", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			a := NewAttributes()
+			if _, err := a.SetHxGetChanged(tt.url); (err == nil) != tt.valid {
+				t.Errorf("SetHxGetChanged(%q) error = %v", tt.url, err)
+			}
+			if _, err := a.SetHxPostChanged(tt.url); (err == nil) != tt.valid {
+				t.Errorf("SetHxPostChanged(%q) error = %v", tt.url, err)
+			}
+			if tt.valid != a.Has("hx-get") || tt.valid != a.Has("hx-post") {
+				t.Errorf("attributes = %v", a)
+			}
+		})
+	}
+}
+
+func TestAttributes_SetHxSwapChanged(t *testing.T) {
+	tests := []struct {
+		mode  string
+		valid bool
+	}{
+		{"innerHTML", true},
+		{"beforeend", true},
+		{"outerHTML swap:1s settle:100ms", true},
+		{"none", true},
+		{"innerhtml", false},
+		{"", false},
+		{"replace", false},
+		{"innerHTML swap", false},
+		{"innerHTML fade:1s", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			a := NewAttributes()
+			changed, err := a.SetHxSwapChanged(tt.mode)
+			if (err == nil) != tt.valid || changed != tt.valid {
+				t.Errorf("SetHxSwapChanged(%q) = %v, %v", tt.mode, changed, err)
+			}
+		})
+	}
+}
+
+func TestHxTriggerAndTarget(t *testing.T) {
+	a := NewAttributes()
+	if _, err := a.SetHxTriggerChanged(" "); err == nil {
+		t.Error("expected error on empty trigger")
+	}
+	if _, err := a.SetHxTargetChanged(""); err == nil {
+		t.Error("expected error on empty target")
+	}
+	if changed, err := a.SetHxTriggerChanged("keyup changed delay:500ms"); !changed || err != nil {
+		t.Errorf("SetHxTriggerChanged() = %v, %v", changed, err)
+	}
+	if changed, _ := a.SetHxTriggerChanged("keyup changed delay:500ms"); changed {
+		t.Error("expected no change")
+	}
+}
@@ -0,0 +1,48 @@
+package html5tag
+
+import (
+	"strconv"
+	"sync"
+)
+
+// IDGenerator generates sequential ids, like "field-1", "field-2", that are unique among the ids it generates.
+// Sequential ids are easier to read in tests and compress better than random ids.
+//
+// Create one for each render pass, like each request, or call Reset between them. The zero value is ready to use,
+// and it is safe to use from multiple goroutines.
+type IDGenerator struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewIDGenerator returns a new IDGenerator.
+func NewIDGenerator() *IDGenerator {
+	return &IDGenerator{}
+}
+
+// Next returns the next id for the given prefix. Each prefix has its own sequence, which starts at 1.
+// An empty prefix uses "id".
+func (g *IDGenerator) Next(prefix string) string {
+	if prefix == "" {
+		prefix = "id"
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.counts == nil {
+		g.counts = make(map[string]int)
+	}
+	g.counts[prefix]++
+	return prefix + "-" + strconv.Itoa(g.counts[prefix])
+}
+
+// Reset starts all the sequences over again.
+func (g *IDGenerator) Reset() {
+	g.mu.Lock()
+	g.counts = nil
+	g.mu.Unlock()
+}
+
+// SetGeneratedID sets the id attribute to the next id that gen generates for prefix.
+func (a Attributes) SetGeneratedID(gen *IDGenerator, prefix string) Attributes {
+	return a.SetID(gen.Next(prefix))
+}
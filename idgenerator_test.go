@@ -0,0 +1,50 @@
+package html5tag
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func ExampleIDGenerator() {
+	gen := NewIDGenerator()
+	fmt.Println(gen.Next("field"), gen.Next("field"), gen.Next("btn"))
+	a := NewAttributes().SetGeneratedID(gen, "field")
+	fmt.Println(a.ID())
+	gen.Reset()
+	fmt.Println(gen.Next("field"))
+	// Output: field-1 field-2 btn-1
+	// field-3
+	// field-1
+}
+
+func TestIDGenerator(t *testing.T) {
+	var gen IDGenerator
+	if id := gen.Next(""); id != "id-1" {
+		t.Errorf("Next() with no prefix = %s", id)
+	}
+
+	ids := make(chan string, 100)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				ids <- gen.Next("x")
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	found := make(map[string]bool)
+	for id := range ids {
+		if found[id] {
+			t.Errorf("duplicate id %s", id)
+		}
+		found[id] = true
+	}
+	if len(found) != 100 {
+		t.Errorf("expected 100 ids, got %d", len(found))
+	}
+}
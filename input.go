@@ -0,0 +1,112 @@
+package html5tag
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// inputTypes are the valid values of the type attribute of an input tag.
+var inputTypes = map[string]bool{
+	"button":         true,
+	"checkbox":       true,
+	"color":          true,
+	"date":           true,
+	"datetime-local": true,
+	"email":          true,
+	"file":           true,
+	"hidden":         true,
+	"image":          true,
+	"month":          true,
+	"number":         true,
+	"password":       true,
+	"radio":          true,
+	"range":          true,
+	"reset":          true,
+	"search":         true,
+	"submit":         true,
+	"tel":            true,
+	"text":           true,
+	"time":           true,
+	"url":            true,
+	"week":           true,
+}
+
+// inputTypeAttributes maps attributes that only apply to certain input types to the types they apply to.
+var inputTypeAttributes = map[string][]string{
+	"accept":         {"file"},
+	"alt":            {"image"},
+	"capture":        {"file"},
+	"checked":        {"checkbox", "radio"},
+	"dirname":        {"search", "text"},
+	"formaction":     {"image", "submit"},
+	"formenctype":    {"image", "submit"},
+	"formmethod":     {"image", "submit"},
+	"formnovalidate": {"image", "submit"},
+	"formtarget":     {"image", "submit"},
+	"height":         {"image"},
+	"list":           {"color", "date", "datetime-local", "email", "month", "number", "range", "search", "tel", "text", "time", "url", "week"},
+	"max":            {"date", "datetime-local", "month", "number", "range", "time", "week"},
+	"maxlength":      {"email", "password", "search", "tel", "text", "url"},
+	"min":            {"date", "datetime-local", "month", "number", "range", "time", "week"},
+	"minlength":      {"email", "password", "search", "tel", "text", "url"},
+	"multiple":       {"email", "file"},
+	"pattern":        {"email", "password", "search", "tel", "text", "url"},
+	"placeholder":    {"email", "number", "password", "search", "tel", "text", "url"},
+	"readonly":       {"date", "datetime-local", "email", "month", "number", "password", "search", "tel", "text", "time", "url", "week"},
+	"required":       {"checkbox", "date", "datetime-local", "email", "file", "month", "number", "password", "radio", "search", "tel", "text", "time", "url", "week"},
+	"size":           {"email", "password", "search", "tel", "text", "url"},
+	"src":            {"image"},
+	"step":           {"date", "datetime-local", "month", "number", "range", "time", "week"},
+	"width":          {"image"},
+}
+
+// IsInputType returns true if t is a valid value for the type attribute of an input tag.
+func IsInputType(t string) bool {
+	return inputTypes[strings.ToLower(t)]
+}
+
+// InputAttributeWarnings returns the names of the attributes in attr that have no effect on an input tag of
+// the given type, like "checked" on a "text" input, sorted by name. These are not errors, since browsers
+// ignore them, but they usually point to a mistake.
+func InputAttributeWarnings(inputType string, attr Attributes) (names []string) {
+	inputType = strings.ToLower(inputType)
+	attr.Range(func(k string, _ string) bool {
+		if types, ok := inputTypeAttributes[k]; ok {
+			found := false
+			for _, t := range types {
+				if t == inputType {
+					found = true
+					break
+				}
+			}
+			if !found {
+				names = append(names, k)
+			}
+		}
+		return true
+	})
+	return
+}
+
+// RenderInput renders an input tag of the given type. It panics if inputType is not a valid input type.
+//
+// See InputAttributeWarnings for a way to check for attributes that do not apply to the input type.
+func RenderInput(inputType string, attr Attributes) string {
+	b := strings.Builder{}
+	_, err := WriteInput(&b, inputType, attr)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteInput writes an input tag of the given type. It returns an error if inputType is not a valid input type.
+func WriteInput(w io.Writer, inputType string, attr Attributes) (n int, err error) {
+	if !IsInputType(inputType) {
+		err = fmt.Errorf("%s is not a valid input type", inputType)
+		return
+	}
+	a := attr.Copy().Set("type", strings.ToLower(inputType))
+	return WriteVoidTag(w, "input", a)
+}
@@ -0,0 +1,27 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleRenderInput() {
+	fmt.Println(RenderInput("checkbox", nil))
+	// Output: <input type="checkbox">
+}
+
+func ExampleInputAttributeWarnings() {
+	fmt.Println(InputAttributeWarnings("text", Attributes{"id": "a", "checked": "", "max": "4", "maxlength": "4"}))
+	// Output: [checked max]
+}
+
+func TestWriteInput(t *testing.T) {
+	b := strings.Builder{}
+	if _, err := WriteInput(&b, "bogus", nil); err == nil {
+		t.Error("expected an error on an invalid input type")
+	}
+	if s := RenderInput("Number", Attributes{"min": "1"}); !strings.Contains(s, `type="number"`) || !strings.Contains(s, `min="1"`) {
+		t.Errorf("RenderInput() = %v", s)
+	}
+}
@@ -0,0 +1,70 @@
+package html5tag
+
+// DefaultInternerSize is the number of strings an Interner holds when it is created with a size of zero or less.
+const DefaultInternerSize = 4096
+
+// Interner holds one copy of each string given to it, so that equal strings can share their memory.
+// It is owned by its caller, typically one for each render or for each parse of a document, and is not safe
+// to use from more than one goroutine at a time. Since it needs no lock, interning is cheap.
+//
+// An Interner holds at most the number of strings it was created with. When it is full, it forgets all of its
+// strings and starts over, so memory use stays bounded even when values are unique, at the cost of some later
+// strings not sharing memory with earlier ones.
+type Interner struct {
+	strings map[string]string
+	maxSize int
+}
+
+// NewInterner returns an Interner that holds at most maxSize strings. If maxSize is zero or less,
+// DefaultInternerSize is used.
+func NewInterner(maxSize int) *Interner {
+	if maxSize <= 0 {
+		maxSize = DefaultInternerSize
+	}
+	return &Interner{strings: make(map[string]string), maxSize: maxSize}
+}
+
+// String returns the interned copy of s, adding s to the interner if it is not there yet.
+func (in *Interner) String(s string) string {
+	if s2, ok := in.strings[s]; ok {
+		return s2
+	}
+	if len(in.strings) >= in.maxSize {
+		in.strings = make(map[string]string, in.maxSize)
+	}
+	in.strings[s] = s
+	return s
+}
+
+// Len returns the number of strings the interner is holding.
+func (in *Interner) Len() int {
+	return len(in.strings)
+}
+
+// Reset releases all the strings that have been interned. Attributes that were interned keep their values.
+func (in *Interner) Reset() {
+	in.strings = make(map[string]string)
+}
+
+// NewInternedAttributes returns new Attributes containing the given attributes, with the keys and values
+// interned by in, so that they share their memory with every other key and value interned by in that is the same.
+// This can greatly reduce the memory used when a large number of tags are kept in memory, and the attributes
+// come from parsing or other sources that create a new string each time, since the same keys and values
+// tend to appear over and over. m may be nil.
+func NewInternedAttributes(in *Interner, m map[string]string) Attributes {
+	a := make(Attributes, len(m))
+	for k, v := range m {
+		a[in.String(k)] = in.String(v)
+	}
+	return a
+}
+
+// Intern interns the keys and values of the attributes in place using in, and returns the attributes.
+//
+// See NewInternedAttributes.
+func (a Attributes) Intern(in *Interner) Attributes {
+	for k, v := range a {
+		a[in.String(k)] = in.String(v)
+	}
+	return a
+}
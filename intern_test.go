@@ -0,0 +1,98 @@
+package html5tag
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestNewInternedAttributes(t *testing.T) {
+	in := NewInterner(0)
+
+	v1 := string([]byte("btn btn-primary"))
+	v2 := string([]byte("btn btn-primary"))
+	a1 := NewInternedAttributes(in, map[string]string{"class": v1})
+	a2 := Attributes{"class": v2}.Intern(in)
+	if a1.Class() != "btn btn-primary" || a2.Class() != "btn btn-primary" {
+		t.Fatal("interning changed the values")
+	}
+	if stringData(a1.Class()) != stringData(a2.Class()) {
+		t.Error("expected interned values to share memory")
+	}
+	if NewInternedAttributes(in, nil).Len() != 0 {
+		t.Error("expected empty attributes")
+	}
+	in.Reset()
+	if in.Len() != 0 {
+		t.Error("expected Reset to release the strings")
+	}
+}
+
+func TestInterner_Bounded(t *testing.T) {
+	in := NewInterner(10)
+	for i := 0; i < 1000; i++ {
+		s := fmt.Sprint(i)
+		if in.String(s) != s {
+			t.Fatal("interning changed the value")
+		}
+		if in.Len() > 10 {
+			t.Fatalf("the interner grew to %d strings", in.Len())
+		}
+	}
+}
+
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+// parsedTags simulates keeping the attributes of a page of parsed tags in memory.
+func parsedTags(n int, in *Interner) []Attributes {
+	tags := make([]Attributes, n)
+	for i := range tags {
+		a, _ := ParseAttributes(fmt.Sprintf(`class="nav-item nav-link" href="/section/%d" role="menuitem" tabindex="-1"`, i%50))
+		if in != nil {
+			a.Intern(in)
+		}
+		tags[i] = a
+	}
+	return tags
+}
+
+func benchmarkRetained(b *testing.B, in *Interner) {
+	var m1, m2 runtime.MemStats
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&m1)
+		tags := parsedTags(10000, in)
+		runtime.GC()
+		runtime.ReadMemStats(&m2)
+		runtime.KeepAlive(tags)
+		b.ReportMetric(float64(m2.HeapAlloc-m1.HeapAlloc)/float64(len(tags)), "retained-B/tag")
+	}
+}
+
+func BenchmarkAttributesRetained(b *testing.B) {
+	benchmarkRetained(b, nil)
+}
+
+func BenchmarkInternedAttributesRetained(b *testing.B) {
+	benchmarkRetained(b, NewInterner(0))
+}
+
+// BenchmarkInternParallel interns in many goroutines at once, each with its own interner, as in concurrent renders.
+func BenchmarkInternParallel(b *testing.B) {
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = fmt.Sprintf("class-%d", i)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		in := NewInterner(0)
+		i := 0
+		for pb.Next() {
+			in.String(values[i%len(values)])
+			i++
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package html5tag
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// scriptJSONEscaper escapes json so that it can be placed inside of a script tag. Escaping "<" prevents a
+// string in the json from closing the script tag with "</script>", or starting a comment with "<!--",
+// and U+2028 and U+2029 are escaped since older javascript does not allow them in strings.
+var scriptJSONEscaper = strings.NewReplacer("<", `\u003c`, "\u2028", `\u2028`, "\u2029", `\u2029`)
+
+// RenderJSONScript marshals v to json and returns it in a <script type="application/json"> tag with the given id,
+// so that the data can be read by javascript on the page using JSON.parse(document.getElementById(id).textContent).
+//
+// The json is escaped for the script context, not html escaped, so it will be parsed correctly no matter what
+// strings v contains. If id is empty, the id attribute is left out, and if id is not a valid id, an error is returned.
+func RenderJSONScript(id string, v interface{}) (string, error) {
+	b := bytes.Buffer{}
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	a := Attributes{"type": "application/json"}
+	if _, err := a.SetIDChanged(id); err != nil {
+		return "", err
+	}
+	s := scriptJSONEscaper.Replace(strings.TrimSuffix(b.String(), "\n"))
+	return RenderTagNoSpace("script", a, s), nil
+}
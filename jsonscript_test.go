@@ -0,0 +1,32 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleRenderJSONScript() {
+	s, _ := RenderJSONScript("data", map[string]string{"msg": "</script><!-- a & b"})
+	fmt.Println(s)
+	// Output: <script id="data" type="application/json">{"msg":"\u003c/script>\u003c!-- a & b"}</script>
+}
+
+func TestRenderJSONScript(t *testing.T) {
+	s, err := RenderJSONScript("", []string{"a\u2028b\u2029c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != `<script type="application/json">["a\u2028b\u2029c"]</script>` {
+		t.Errorf("RenderJSONScript() = %s", s)
+	}
+	if strings.ContainsAny(s, "\u2028\u2029") {
+		t.Error("line separators should be escaped")
+	}
+	if _, err = RenderJSONScript("a", make(chan int)); err == nil {
+		t.Error("expected an error on a value that cannot be marshaled")
+	}
+	if _, err = RenderJSONScript("a b", 1); err == nil {
+		t.Error("expected an error on an invalid id")
+	}
+}
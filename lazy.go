@@ -0,0 +1,131 @@
+package html5tag
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// AttributeResolver provides the values of attributes set with SetAttributeFunc. ResolveAttribute is called with
+// the function name given to SetAttributeFunc each time the attributes are rendered with a context that holds
+// the resolver, and returns the value to write.
+type AttributeResolver interface {
+	ResolveAttribute(funcName string) string
+}
+
+// AttributeResolverFunc is a function that is an AttributeResolver.
+type AttributeResolverFunc func(funcName string) string
+
+// ResolveAttribute calls f.
+func (f AttributeResolverFunc) ResolveAttribute(funcName string) string {
+	return f(funcName)
+}
+
+// AttributeValues is an AttributeResolver that looks up each function name in the map. Names that are
+// not in the map resolve to an empty value.
+type AttributeValues map[string]string
+
+// ResolveAttribute returns the value for funcName.
+func (v AttributeValues) ResolveAttribute(funcName string) string {
+	return v[funcName]
+}
+
+// lazyPrefix starts the value of an attribute whose value comes from an AttributeResolver.
+// The rest of the value is the name of the function.
+const lazyPrefix = "**GORADD-LAZY**"
+
+type attributeResolverKey struct{}
+
+// WithAttributeResolver returns a copy of ctx that holds r. Pass the returned context to RenderTagContext,
+// WriteTagContext or Resolve so that attributes set with SetAttributeFunc get their values from r.
+//
+// Create a resolver for each request so that values like a nonce are never shared between requests.
+func WithAttributeResolver(ctx context.Context, r AttributeResolver) context.Context {
+	return context.WithValue(ctx, attributeResolverKey{}, r)
+}
+
+// AttributeResolverFromContext returns the AttributeResolver in ctx, or nil if it has none.
+func AttributeResolverFromContext(ctx context.Context) AttributeResolver {
+	r, _ := ctx.Value(attributeResolverKey{}).(AttributeResolver)
+	return r
+}
+
+// SetAttributeFunc sets the attribute so that its value comes from the AttributeResolver of the context the
+// attributes are rendered with, using funcName to look it up. Use this for values that are only known when the
+// html is written, like a nonce or a token, so that attributes that use them can be built ahead of time.
+//
+// Get returns a placeholder for the attribute, and not its value. Writing the attributes without a resolver,
+// including with String, JSXString, Selector or ToMap, writes an empty value in place of the placeholder.
+//
+// The class, style and id attributes cannot be set this way, since their values are checked and combined with
+// other values when they are changed, and SetAttributeFunc will panic if attr is one of them. A placeholder is
+// never combined with other values. Merge and AddValues replace it, or replace a value with it.
+func (a Attributes) SetAttributeFunc(attr string, funcName string) Attributes {
+	switch attr {
+	case "class", "style", "id":
+		panic(fmt.Errorf("the %s attribute cannot be set with SetAttributeFunc", attr))
+	}
+	return a.Set(attr, lazyPrefix+funcName)
+}
+
+// Resolve returns the attributes with the values set by SetAttributeFunc replaced with the values from the
+// AttributeResolver in ctx, or with empty values if ctx has no resolver. If there are no such values, a itself is
+// returned, otherwise a copy is returned and a is not changed.
+//
+// Use it to resolve the attributes before calling a writer that does not take a context, like JSXString or ToMap.
+func (a Attributes) Resolve(ctx context.Context) Attributes {
+	r := AttributeResolverFromContext(ctx)
+	var out Attributes
+	for k, v := range a {
+		if !isLazyValue(v) {
+			continue
+		}
+		if out == nil {
+			out = a.Copy()
+		}
+		if r == nil {
+			out[k] = ""
+		} else {
+			out[k] = r.ResolveAttribute(v[len(lazyPrefix):])
+		}
+	}
+	if out == nil {
+		return a
+	}
+	return out
+}
+
+// RenderTagContext is like RenderTag, but resolves the values set by SetAttributeFunc using ctx.
+func RenderTagContext(ctx context.Context, tag string, attr Attributes, innerHtml string) string {
+	return RenderTag(tag, attr.Resolve(ctx), innerHtml)
+}
+
+// RenderVoidTagContext is like RenderVoidTag, but resolves the values set by SetAttributeFunc using ctx.
+func RenderVoidTagContext(ctx context.Context, tag string, attr Attributes) string {
+	return RenderVoidTag(tag, attr.Resolve(ctx))
+}
+
+// WriteTagContext is like WriteTag, but resolves the values set by SetAttributeFunc using ctx.
+func WriteTagContext(ctx context.Context, w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo) (n int, err error) {
+	return WriteTag(w, tag, attr.Resolve(ctx), innerHtml)
+}
+
+// WriteVoidTagContext is like WriteVoidTag, but resolves the values set by SetAttributeFunc using ctx.
+func WriteVoidTagContext(ctx context.Context, w io.Writer, tag string, attr Attributes) (n int, err error) {
+	return WriteVoidTag(w, tag, attr.Resolve(ctx))
+}
+
+// isLazyValue returns true if v is a placeholder set by SetAttributeFunc.
+func isLazyValue(v string) bool {
+	return strings.HasPrefix(v, lazyPrefix)
+}
+
+// resolveValue returns v, or an empty value if v is a placeholder that was not resolved, so that
+// placeholders are never written.
+func resolveValue(v string) string {
+	if isLazyValue(v) {
+		return ""
+	}
+	return v
+}
@@ -0,0 +1,171 @@
+package html5tag
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func ExampleAttributes_SetAttributeFunc() {
+	a := NewAttributes().SetAttributeFunc("nonce", "nonce")
+
+	ctx := WithAttributeResolver(context.Background(), AttributeValues{"nonce": "abc"})
+	fmt.Println(RenderTagContext(ctx, "script", a, ""))
+	ctx = WithAttributeResolver(context.Background(), AttributeValues{"nonce": "def"})
+	fmt.Println(RenderTagContext(ctx, "script", a, ""))
+	// Output: <script nonce="abc"></script>
+	// <script nonce="def"></script>
+}
+
+func TestAttributeFunc(t *testing.T) {
+	a := Attributes{"id": "x"}.SetAttributeFunc("value", "token").SetAttributeFunc("title", "missing")
+	ctx := WithAttributeResolver(context.Background(), AttributeResolverFunc(func(name string) string {
+		if name == "token" {
+			return `a"b`
+		}
+		return ""
+	}))
+	r := a.Resolve(ctx)
+	if r.Get("value") != `a"b` || r.Get("title") != "" {
+		t.Errorf("Resolve() = %v", r)
+	}
+	if !isLazyValue(a.Get("value")) {
+		t.Error("Resolve() changed the original attributes")
+	}
+	plain := Attributes{"id": "x"}
+	if p := plain.Resolve(ctx); len(p) != 1 || p.Get("id") != "x" {
+		t.Errorf("Resolve() = %v", p)
+	}
+
+	want := `<input id="x" value="a&#34;b" title="">`
+	if s := RenderVoidTagContext(ctx, "input", a); s != want {
+		t.Errorf("RenderVoidTagContext() = %s, want %s", s, want)
+	}
+	b := strings.Builder{}
+	if _, err := WriteVoidTagContext(ctx, &b, "input", a); err != nil || b.String() != want {
+		t.Errorf("WriteVoidTagContext() = %s, %v", b.String(), err)
+	}
+	b.Reset()
+	if _, err := WriteTagContext(ctx, &b, "div", a, nil); err != nil || b.String() != RenderTagContext(ctx, "div", a, "") {
+		t.Errorf("WriteTagContext() = %s, %v", b.String(), err)
+	}
+	if s := r.JSXString(); s != `id="x" value="a&#34;b" title=""` {
+		t.Errorf("resolved JSXString() = %s", s)
+	}
+	if s := r.Selector("input"); s != `input#x[value="a\"b"][title]` {
+		t.Errorf("resolved Selector() = %s", s)
+	}
+	if m := r.ToMap(); m["value"] != `a"b` {
+		t.Errorf("resolved ToMap() = %v", m)
+	}
+}
+
+func TestAttributeFunc_Unresolved(t *testing.T) {
+	// without a resolver, every writer writes an empty value and never the placeholder
+	a := Attributes{"id": "x"}.SetAttributeFunc("value", "token").SetAttributeFunc("disabled", "token")
+	b := strings.Builder{}
+	_, _ = a.WriteToQuoted(&b, '\'')
+	outputs := map[string]string{
+		"String":        a.String(),
+		"AppendBytes":   string(a.AppendBytes(nil)),
+		"WriteToQuoted": b.String(),
+		"RenderTag":     RenderTag("div", a, ""),
+		"JSXString":     a.JSXString(),
+		"Selector":      a.Selector("input"),
+		"Resolve":       a.Resolve(context.Background()).String(),
+	}
+	for name, s := range outputs {
+		if strings.Contains(s, lazyPrefix) || strings.Contains(s, "token") {
+			t.Errorf("%s() wrote the placeholder: %s", name, s)
+		}
+	}
+	for k, v := range a.ToMap() {
+		if v != "" && k != "id" {
+			t.Errorf("ToMap() wrote the placeholder for %s: %s", k, v)
+		}
+	}
+	if s := a.String(); s != `id="x" value="" disabled` {
+		t.Errorf("String() = %s", s)
+	}
+	if a.renderedLen() != len(a.String()) {
+		t.Errorf("renderedLen() = %d, want %d", a.renderedLen(), len(a.String()))
+	}
+}
+
+func TestAttributeFunc_Rejected(t *testing.T) {
+	for _, name := range []string{"class", "style", "id"} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SetAttributeFunc(%q) did not panic", name)
+				}
+			}()
+			NewAttributes().SetAttributeFunc(name, "f")
+		})
+	}
+}
+
+func TestAttributeFunc_Edits(t *testing.T) {
+	placeholder := NewAttributes().SetAttributeFunc("title", "f").Get("title")
+	ctx := WithAttributeResolver(context.Background(), AttributeValues{"f": "v"})
+
+	tests := []struct {
+		name string
+		edit func() Attributes
+		want string
+	}{
+		{"Merge a placeholder over a class", func() Attributes {
+			return Attributes{"class": "a"}.Merge(Attributes{"class": placeholder})
+		}, `class="v"`},
+		{"Merge a class over a placeholder", func() Attributes {
+			return Attributes{"class": placeholder}.Merge(Attributes{"class": "c"})
+		}, `class="c"`},
+		{"Merge a placeholder over a style", func() Attributes {
+			return Attributes{"style": "color:red"}.Merge(Attributes{"style": placeholder})
+		}, `style="v"`},
+		{"Merge keeps other placeholders", func() Attributes {
+			return NewAttributes().SetAttributeFunc("title", "f").Merge(Attributes{"class": "c"})
+		}, `class="c" title="v"`},
+		{"Override", func() Attributes {
+			return Attributes{"title": "a"}.Override(Attributes{"title": placeholder})
+		}, `title="v"`},
+		{"AddClass to a placeholder", func() Attributes {
+			return Attributes{"class": placeholder}.AddClass("c")
+		}, `class="c"`},
+		{"AddValues to a placeholder", func() Attributes {
+			return NewAttributes().SetAttributeFunc("rel", "f").AddValues("rel", "noopener")
+		}, `rel="noopener"`},
+		{"AddValues a placeholder", func() Attributes {
+			return Attributes{"rel": "noopener"}.AddValues("rel", placeholder)
+		}, `rel="v"`},
+		{"SetStyle over a placeholder", func() Attributes {
+			return Attributes{"style": placeholder}.SetStyle("color", "red")
+		}, `style="color:red"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.edit().Resolve(ctx).String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttributeFunc_Concurrent(t *testing.T) {
+	a := NewAttributes().SetAttributeFunc("nonce", "nonce")
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			nonce := fmt.Sprint("n", i)
+			ctx := WithAttributeResolver(context.Background(), AttributeValues{"nonce": nonce})
+			if s := RenderTagContext(ctx, "script", a, ""); s != `<script nonce="`+nonce+`"></script>` {
+				t.Errorf("RenderTagContext() = %s", s)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
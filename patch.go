@@ -0,0 +1,73 @@
+package html5tag
+
+import "sort"
+
+// AttributeDiff describes the changes that turn one set of attributes into another.
+type AttributeDiff struct {
+	// Set holds the attributes that were added or whose values changed, with their new values.
+	Set map[string]string
+	// Removed holds the names of the attributes that were removed, in sorted order.
+	Removed []string
+}
+
+// IsEmpty returns true if there are no changes.
+func (d AttributeDiff) IsEmpty() bool {
+	return len(d.Set) == 0 && len(d.Removed) == 0
+}
+
+// Diff returns the changes needed to turn a into other. Values are compared as strings, so a class or style
+// attribute with the same classes or properties in a different order is reported as changed.
+// Attributes set to FalseValue are not rendered, so they are treated as if they were not there.
+func (a Attributes) Diff(other Attributes) AttributeDiff {
+	var d AttributeDiff
+	for k, v := range other {
+		if v == FalseValue {
+			continue
+		}
+		if v2, ok := a[k]; !ok || v2 != v {
+			if d.Set == nil {
+				d.Set = make(map[string]string)
+			}
+			d.Set[k] = v
+		}
+	}
+	for k, v := range a {
+		if v == FalseValue {
+			continue
+		}
+		if v2, ok := other[k]; !ok || v2 == FalseValue {
+			d.Removed = append(d.Removed, k)
+		}
+	}
+	sort.Strings(d.Removed)
+	return d
+}
+
+// TagPatch describes how a tag changed between two renders.
+type TagPatch struct {
+	// AttributesChanged is true if the attributes of the tag changed.
+	AttributesChanged bool
+	// Attributes holds the changes to the attributes.
+	Attributes AttributeDiff
+	// InnerChanged is true if the inner html changed.
+	InnerChanged bool
+	// Inner is the new inner html, if it changed.
+	Inner string
+}
+
+// IsEmpty returns true if nothing changed.
+func (p TagPatch) IsEmpty() bool {
+	return !p.AttributesChanged && !p.InnerChanged
+}
+
+// PatchTag compares the old and new attributes and inner html of a tag, and returns what changed, so that
+// only the changed parts of the tag need to be sent to a browser that already has the old version.
+func PatchTag(oldAttr, newAttr Attributes, oldInner, newInner string) TagPatch {
+	p := TagPatch{Attributes: oldAttr.Diff(newAttr)}
+	p.AttributesChanged = !p.Attributes.IsEmpty()
+	if oldInner != newInner {
+		p.InnerChanged = true
+		p.Inner = newInner
+	}
+	return p
+}
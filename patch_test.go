@@ -0,0 +1,40 @@
+package html5tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExamplePatchTag() {
+	p := PatchTag(
+		Attributes{"id": "a", "class": "x", "disabled": ""},
+		Attributes{"id": "a", "class": "y", "title": "t"},
+		"Hello", "Hello",
+	)
+	fmt.Println(p.AttributesChanged, p.Attributes.Set, p.Attributes.Removed, p.InnerChanged)
+	// Output: true map[class:y title:t] [disabled] false
+}
+
+func TestPatchTag(t *testing.T) {
+	p := PatchTag(Attributes{"id": "a"}, Attributes{"id": "a"}, "x", "x")
+	if !p.IsEmpty() || p.Attributes.Set != nil || p.Attributes.Removed != nil {
+		t.Errorf("expected an empty patch, got %v", p)
+	}
+	p = PatchTag(nil, nil, "x", "y")
+	if p.AttributesChanged || !p.InnerChanged || p.Inner != "y" {
+		t.Errorf("expected only an inner change, got %v", p)
+	}
+	p = PatchTag(nil, Attributes{"id": "a"}, "", "")
+	if !p.AttributesChanged || p.Attributes.Set["id"] != "a" || p.InnerChanged {
+		t.Errorf("expected only an attribute change, got %v", p)
+	}
+
+	p = PatchTag(Attributes{"hidden": "", "disabled": FalseValue}, Attributes{"hidden": FalseValue, "disabled": FalseValue}, "", "")
+	if !p.AttributesChanged || p.Attributes.Set != nil || len(p.Attributes.Removed) != 1 || p.Attributes.Removed[0] != "hidden" {
+		t.Errorf("expected FalseValue to be a removal, got %v", p)
+	}
+	p = PatchTag(Attributes{"disabled": FalseValue}, Attributes{"disabled": ""}, "", "")
+	if v, ok := p.Attributes.Set["disabled"]; !ok || v != "" || p.Attributes.Removed != nil {
+		t.Errorf("expected an added attribute, got %v", p)
+	}
+}
@@ -0,0 +1,165 @@
+package html5tag
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// TagNode is a node in a tree of tags that can be rendered by RenderTagTreePretty.
+type TagNode struct {
+	// Tag is the name of the tag. If it is empty, the node is a text node.
+	Tag string
+	// Attributes are the attributes of the tag.
+	Attributes Attributes
+	// Text is the content of a text node. It will be escaped, except inside of script and style tags.
+	Text string
+	// Children are the nodes inside of the tag.
+	Children []TagNode
+}
+
+// PrettyOptions controls the output of RenderTagTreePretty.
+type PrettyOptions struct {
+	// IndentWidth is the number of spaces to indent each level of the tree. Zero means 2.
+	IndentWidth int
+	// MaxWidth is the column that lines should fit within. Zero means 80.
+	MaxWidth int
+	// Renderer, if not nil, is the Renderer whose options apply to each tag.
+	Renderer *Renderer
+}
+
+// prettyBlockTags are tags that are always placed on their own line, in addition to the flowTags.
+var prettyBlockTags = makeSet("li", "dt", "dd", "tr", "td", "th", "thead", "tbody", "tfoot", "caption",
+	"colgroup", "option", "optgroup", "html", "head", "body", "title", "meta", "link", "script", "style",
+	"template", "figcaption", "legend", "summary")
+
+// preformattedTags are tags whose content is written exactly as given.
+var preformattedTags = makeSet("pre", "textarea", "script", "style")
+
+// rawTextParents are tags whose text content is not escaped.
+var rawTextParents = makeSet("script", "style")
+
+var whitespaceCollapser = regexp.MustCompile(`\s+`)
+
+// RenderTagTreePretty renders a tree of tags as human-readable html.
+//
+// Each level of the tree is indented by opts.IndentWidth spaces. A tag that contains only text and inline tags,
+// like span or b, is written on one line if it fits within opts.MaxWidth, otherwise each of its children
+// is written on its own line. Block tags, like div or li, always start on a new line. If an opening tag does not
+// fit within opts.MaxWidth, its attributes are written one per line. The content of pre, textarea, script and
+// style tags is written exactly as given.
+//
+// Since white space is added and collapsed, the output is for reading, and can display slightly differently
+// than the same tree rendered without formatting.
+func RenderTagTreePretty(root TagNode, opts PrettyOptions) string {
+	p := prettyPrinter{indentWidth: opts.IndentWidth, maxWidth: opts.MaxWidth, r: opts.Renderer}
+	if p.indentWidth <= 0 {
+		p.indentWidth = 2
+	}
+	if p.maxWidth <= 0 {
+		p.maxWidth = 80
+	}
+	p.node(root, 0)
+	return strings.Join(p.lines, "\n")
+}
+
+type prettyPrinter struct {
+	indentWidth int
+	maxWidth    int
+	r           *Renderer
+	lines       []string
+}
+
+func (p *prettyPrinter) node(n TagNode, depth int) {
+	indent := strings.Repeat(" ", depth*p.indentWidth)
+	if n.Tag == "" {
+		for _, l := range strings.Split(n.Text, "\n") {
+			if l = whitespaceCollapser.ReplaceAllString(strings.TrimSpace(l), " "); l != "" {
+				p.lines = append(p.lines, indent+html.EscapeString(l))
+			}
+		}
+		return
+	}
+
+	attr := p.r.apply(n.Tag, n.Attributes)
+	if p.isInline(n) {
+		s := p.inline(n, attr, false)
+		if preformattedTags[n.Tag] || !strings.Contains(s, "\n") && len(indent)+len(s) <= p.maxWidth {
+			p.lines = append(p.lines, indent+s)
+			return
+		}
+	}
+	p.openTag(n.Tag, attr, indent)
+	if voidTags[n.Tag] {
+		return
+	}
+	for _, c := range n.Children {
+		p.node(c, depth+1)
+	}
+	p.lines = append(p.lines, indent+"</"+n.Tag+">")
+}
+
+// isInline returns true if the node can be written on one line, because none of the tags in it are block tags.
+func (p *prettyPrinter) isInline(n TagNode) bool {
+	if preformattedTags[n.Tag] {
+		return true
+	}
+	for _, c := range n.Children {
+		if c.Tag != "" && (flowTags[c.Tag] || prettyBlockTags[c.Tag] || !p.isInline(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// inline returns the node written on one line.
+func (p *prettyPrinter) inline(n TagNode, attr Attributes, preformatted bool) string {
+	b := strings.Builder{}
+	b.WriteString(openTagString(n.Tag, attr))
+	if voidTags[n.Tag] {
+		return b.String()
+	}
+	preformatted = preformatted || preformattedTags[n.Tag]
+	for _, c := range n.Children {
+		if c.Tag != "" {
+			b.WriteString(p.inline(c, p.r.apply(c.Tag, c.Attributes), preformatted))
+			continue
+		}
+		text := c.Text
+		if !preformatted {
+			text = whitespaceCollapser.ReplaceAllString(text, " ")
+		}
+		if !rawTextParents[n.Tag] {
+			text = html.EscapeString(text)
+		}
+		b.WriteString(text)
+	}
+	b.WriteString("</" + n.Tag + ">")
+	return b.String()
+}
+
+// openTag adds the opening tag to the lines, putting each attribute on its own line if it does not fit.
+func (p *prettyPrinter) openTag(tag string, attr Attributes, indent string) {
+	s := openTagString(tag, attr)
+	keys := attr.renderedKeys()
+	if len(indent)+len(s) <= p.maxWidth || len(keys) < 2 {
+		p.lines = append(p.lines, indent+s)
+		return
+	}
+	p.lines = append(p.lines, indent+"<"+tag)
+	attrIndent := indent + strings.Repeat(" ", p.indentWidth)
+	for i, k := range keys {
+		l := attrIndent + Attributes{k: attr[k]}.String()
+		if i == len(keys)-1 {
+			l += ">"
+		}
+		p.lines = append(p.lines, l)
+	}
+}
+
+func openTagString(tag string, attr Attributes) string {
+	if !attr.isRendered() {
+		return "<" + tag + ">"
+	}
+	return "<" + tag + " " + attr.String() + ">"
+}
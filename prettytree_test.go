@@ -0,0 +1,67 @@
+package html5tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleRenderTagTreePretty() {
+	root := TagNode{Tag: "div", Attributes: Attributes{"class": "card"}, Children: []TagNode{
+		{Tag: "p", Children: []TagNode{
+			{Text: "Hello "},
+			{Tag: "b", Children: []TagNode{{Text: "world"}}},
+		}},
+		{Tag: "ul", Children: []TagNode{
+			{Tag: "li", Children: []TagNode{{Text: "One"}}},
+			{Tag: "li", Children: []TagNode{{Text: "Two & three"}}},
+		}},
+		{Tag: "a", Attributes: Attributes{"href": "https://example.com/a/long/path", "class": "link", "title": "Example"}},
+	}}
+	fmt.Println(RenderTagTreePretty(root, PrettyOptions{IndentWidth: 2, MaxWidth: 40}))
+	// Output:
+	// <div class="card">
+	//   <p>Hello <b>world</b></p>
+	//   <ul>
+	//     <li>One</li>
+	//     <li>Two &amp; three</li>
+	//   </ul>
+	//   <a
+	//     class="link"
+	//     href="https://example.com/a/long/path"
+	//     title="Example">
+	//   </a>
+	// </div>
+}
+
+func TestRenderTagTreePretty(t *testing.T) {
+	tests := []struct {
+		name string
+		root TagNode
+		opts PrettyOptions
+		want string
+	}{
+		{"text", TagNode{Text: "  a\n  b  "}, PrettyOptions{}, "a\nb"},
+		{"void", TagNode{Tag: "br"}, PrettyOptions{}, "<br>"},
+		{"empty", TagNode{Tag: "div"}, PrettyOptions{}, "<div></div>"},
+		{"indent width", TagNode{Tag: "div", Children: []TagNode{{Tag: "div"}}}, PrettyOptions{IndentWidth: 4},
+			"<div>\n    <div></div>\n</div>"},
+		{"too wide", TagNode{Tag: "span", Children: []TagNode{{Text: "0123456789"}, {Tag: "i", Children: []TagNode{{Text: "x"}}}}},
+			PrettyOptions{MaxWidth: 10}, "<span>\n  0123456789\n  <i>x</i>\n</span>"},
+		{"pre", TagNode{Tag: "pre", Children: []TagNode{{Text: "a\n  b"}}}, PrettyOptions{}, "<pre>a\n  b</pre>"},
+		{"script", TagNode{Tag: "script", Children: []TagNode{{Text: "if (a < b) {}"}}}, PrettyOptions{},
+			"<script>if (a < b) {}</script>"},
+		{"wrapped false value", TagNode{Tag: "input", Attributes: Attributes{"id": "name", "disabled": FalseValue, "type": "text"}},
+			PrettyOptions{MaxWidth: 10}, "<input\n  id=\"name\"\n  type=\"text\">"},
+		{"only false values", TagNode{Tag: "input", Attributes: Attributes{"disabled": FalseValue, "hidden": FalseValue}},
+			PrettyOptions{MaxWidth: 5}, "<input>"},
+		{"renderer", TagNode{Tag: "form", Attributes: Attributes{"method": "get"}, Children: []TagNode{{Tag: "input", Attributes: Attributes{"type": "text"}}}},
+			PrettyOptions{Renderer: &Renderer{OmitDefaults: true}}, "<form><input></form>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderTagTreePretty(tt.root, tt.opts); got != tt.want {
+				t.Errorf("RenderTagTreePretty() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package html5tag
+
+import (
+	"io"
+	"strings"
+)
+
+// RenderHook is a function that is called just before a tag is written, and can change the attributes of the tag.
+// attr is a copy of the attributes being rendered, so the hook may change it and return it.
+// The returned attributes are the ones that are written.
+type RenderHook func(tag string, attr Attributes) Attributes
+
+// A Renderer writes tags with options that apply to every tag it writes, like hooks that enforce a policy
+// on the attributes of all generated html. The package level functions, like RenderTag, write tags with no options.
+//
+// A Renderer is a plain value, so different parts of a program, and different tests, can use different options.
+// Set the options before using it. It is then safe to use from multiple goroutines, as long as the options are
+// not changed. A nil *Renderer writes tags with no options.
+//
+//	r := &html5tag.Renderer{OmitDefaults: true}
+//	s := r.RenderVoidTag("input", html5tag.Attributes{"type": "text"}) // <input>
+type Renderer struct {
+	// Hooks are called, in order, for every tag that is written. Use them to apply a policy to all generated html,
+	// like adding rel="noopener" to links to other sites.
+	Hooks []RenderHook
+
+	// DebugSource turns on source attributes. When true, every tag written gets a data-html5tag-source attribute
+	// containing the file and line of the code outside of this package that rendered it, so that generated html
+	// can be traced back to the code that produced it. This is meant for development only.
+	// A hook can remove the attribute from particular tags, since hooks are called after it is added.
+	DebugSource bool
+
+	// OmitDefaults leaves out attributes whose values are the defaults that HTML5 gives them on that element,
+	// like type="text" on an input or method="get" on a form, to make the output smaller.
+	//
+	// Only defaults that do not depend on the rest of the document are left out. For example,
+	// target="_self" is kept, since a base tag can change the default target, and rel="stylesheet" is kept,
+	// since rel has no default. The start of a reversed ol is kept, since its default is the number of items.
+	// Values are compared without regard to case, since these are all enumerated or numeric values.
+	// Hooks are called before defaults are removed.
+	OmitDefaults bool
+
+	// RequiredIndicator is the html that RenderLabelRequired adds to the end of the label of a required field.
+	// If empty, DefaultRequiredIndicator is used.
+	RequiredIndicator string
+}
+
+// apply returns the attributes that should be rendered for the given tag after adding the
+// debug source, calling the hooks and removing default values. attr is not changed.
+func (r *Renderer) apply(tag string, attr Attributes) Attributes {
+	if r == nil {
+		return attr
+	}
+	if len(r.Hooks) != 0 || r.DebugSource {
+		attr = attr.Copy()
+		if r.DebugSource {
+			if src := callerSource(); src != "" {
+				attr[DebugSourceAttribute] = src
+			}
+		}
+		for _, h := range r.Hooks {
+			attr = h(tag, attr)
+		}
+	}
+	if r.OmitDefaults {
+		attr = omitDefaults(tag, attr)
+	}
+	return attr
+}
+
+// requiredIndicator returns the html to add to the label of a required field.
+func (r *Renderer) requiredIndicator() string {
+	if r == nil || r.RequiredIndicator == "" {
+		return DefaultRequiredIndicator
+	}
+	return r.RequiredIndicator
+}
+
+// RenderTag renders a standard html tag with the options of the renderer. See the RenderTag function.
+func (r *Renderer) RenderTag(tag string, attr Attributes, innerHtml string) string {
+	b := strings.Builder{}
+	if _, err := r.WriteTag(&b, tag, attr, strings.NewReader(innerHtml)); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteTag writes a standard html tag with the options of the renderer. See the WriteTag function.
+func (r *Renderer) WriteTag(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo) (n int, err error) {
+	return writeTag(r, w, tag, attr, innerHtml, false, WhitespaceNewline, false)
+}
+
+// RenderTagNoSpace renders a tag with no space around its inner html, with the options of the renderer.
+// See the RenderTagNoSpace function.
+func (r *Renderer) RenderTagNoSpace(tag string, attr Attributes, innerHtml string) string {
+	b := strings.Builder{}
+	if _, err := r.WriteTagNoSpace(&b, tag, attr, strings.NewReader(innerHtml)); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteTagNoSpace writes a tag with no space around its inner html, with the options of the renderer.
+// See the WriteTagNoSpace function.
+func (r *Renderer) WriteTagNoSpace(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo) (n int, err error) {
+	return writeTag(r, w, tag, attr, innerHtml, false, WhitespaceNone, false)
+}
+
+// RenderVoidTag renders a void tag with the options of the renderer. See the RenderVoidTag function.
+func (r *Renderer) RenderVoidTag(tag string, attr Attributes) string {
+	b := strings.Builder{}
+	if _, err := r.WriteVoidTag(&b, tag, attr); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteVoidTag writes a void tag with the options of the renderer. See the WriteVoidTag function.
+func (r *Renderer) WriteVoidTag(w io.Writer, tag string, attr Attributes) (n int, err error) {
+	return writeTag(r, w, tag, attr, nil, true, WhitespaceNewline, false)
+}
+
+// RenderLabelRequired renders a label with the options of the renderer, adding the RequiredIndicator of the
+// renderer to the label if required is true. See the RenderLabelRequired function.
+func (r *Renderer) RenderLabelRequired(labelAttributes Attributes, label string, ctrlHtml string, mode LabelDrawingMode, required bool) string {
+	b := strings.Builder{}
+
+	var wto io.WriterTo
+	if ctrlHtml != "" {
+		wto = strings.NewReader(ctrlHtml)
+	}
+	if _, err := r.WriteLabelRequired(&b, labelAttributes, label, wto, mode, required); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteLabelRequired writes a label with the options of the renderer, adding the RequiredIndicator of the
+// renderer to the label if required is true. See the WriteLabelRequired function.
+func (r *Renderer) WriteLabelRequired(w io.Writer, labelAttributes Attributes, label string, ctrlHtml io.WriterTo, mode LabelDrawingMode, required bool) (n int, err error) {
+	return writeLabelRequired(r, w, labelAttributes, label, ctrlHtml, mode, required)
+}
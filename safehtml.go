@@ -0,0 +1,66 @@
+package html5tag
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// SafeHTML is a string of html that is known to be safe, like the output of another html5tag function,
+// and so will not be escaped when used as content.
+//
+// SafeHTML implements io.WriterTo, so it can be passed directly as the innerHtml of WriteTag and similar functions.
+type SafeHTML string
+
+// WriteTo implements the io.WriterTo interface.
+func (h SafeHTML) WriteTo(w io.Writer) (n int64, err error) {
+	n2, err := io.WriteString(w, string(h))
+	return int64(n2), err
+}
+
+// escapedText is text that is escaped as it is written.
+type escapedText string
+
+// WriteTo implements the io.WriterTo interface.
+func (t escapedText) WriteTo(w io.Writer) (n int64, err error) {
+	n2, err := io.WriteString(w, html.EscapeString(string(t)))
+	return int64(n2), err
+}
+
+// Content returns an io.WriterTo that writes the given items one after the other, and is suitable as the innerHtml
+// of WriteTag. SafeHTML items and io.WriterTo items are written as is, and all other items, including strings,
+// are converted to a string and escaped.
+//
+// This lets you mix trusted and untrusted content:
+//
+//	WriteTag(w, "p", nil, Content("Hello ", userName, SafeHTML("<br>")))
+func Content(items ...interface{}) io.WriterTo {
+	wto := make(writerItems, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case io.WriterTo:
+			wto = append(wto, v)
+		case string:
+			wto = append(wto, escapedText(v))
+		default:
+			wto = append(wto, escapedText(fmt.Sprint(v)))
+		}
+	}
+	return wto
+}
+
+// RenderTagContent is like RenderTag, but takes its inner content as a list of items that are treated as described
+// in Content. Plain strings are escaped, and SafeHTML is not.
+func RenderTagContent(tag string, attr Attributes, items ...interface{}) string {
+	b := strings.Builder{}
+	var wto io.WriterTo
+	if len(items) > 0 {
+		wto = Content(items...)
+	}
+	_, err := WriteTag(&b, tag, attr, wto)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleRenderTagContent() {
+	s := RenderTagContent("p", nil, "Tom & Jerry", SafeHTML("<br>"), 5)
+	fmt.Println(s)
+	// Output:
+	// <p>
+	// Tom &amp; Jerry<br>5
+	// </p>
+}
+
+func TestSafeHTML(t *testing.T) {
+	b := strings.Builder{}
+	n, err := WriteTagNoSpace(&b, "div", nil, SafeHTML("<b>a</b>"))
+	if err != nil || b.String() != "<div><b>a</b></div>" || n != b.Len() {
+		t.Errorf("WriteTagNoSpace() with SafeHTML = %q, %d, %v", b.String(), n, err)
+	}
+
+	b.Reset()
+	n, err = WriteTagNoSpace(&b, "div", nil, Content("<", strings.NewReader("<i>")))
+	if err != nil || b.String() != "<div>&lt;<i></div>" || n != b.Len() {
+		t.Errorf("WriteTagNoSpace() with Content = %q, %d, %v", b.String(), n, err)
+	}
+	if s := RenderTagContent("div", nil); s != "<div></div>" {
+		t.Errorf("RenderTagContent() with no content = %q", s)
+	}
+}
@@ -0,0 +1,123 @@
+package html5tag
+
+import (
+	"io"
+	"strings"
+)
+
+// SpanKind describes the part of a rendered tag that a SourceSpan covers.
+type SpanKind int
+
+const (
+	// SpanElement covers the whole tag, from the start of the opening tag to the end of the closing tag.
+	SpanElement SpanKind = iota
+	// SpanOpenTag covers the opening tag, including its attributes.
+	SpanOpenTag
+	// SpanAttribute covers one attribute, including its name and value.
+	SpanAttribute
+	// SpanContent covers the inner html.
+	SpanContent
+	// SpanCloseTag covers the closing tag.
+	SpanCloseTag
+)
+
+// SourceSpan is a range of bytes in rendered html, and the part of the tag that produced it.
+type SourceSpan struct {
+	// Start is the offset of the first byte of the span.
+	Start int
+	// End is the offset just past the last byte of the span.
+	End int
+	// Kind is the part of the tag that the span covers.
+	Kind SpanKind
+	// Tag is the name of the tag.
+	Tag string
+	// Attribute is the name of the attribute, for a SpanAttribute.
+	Attribute string
+}
+
+// RenderTagWithMap renders a tag the same way as RenderTag, and also returns the spans of bytes in the html that
+// came from each part of the tag: the whole element, the opening tag, each attribute, the inner html, and
+// the closing tag, in that order. Use it to map positions in the html back to what generated them.
+//
+// Void tags, like "br", are written without innerHtml or a closing tag, the same as RenderVoidTag,
+// and have no content or closing tag span.
+func RenderTagWithMap(tag string, attr Attributes, innerHtml string) (html string, spans []SourceSpan) {
+	return renderTagWithMap(nil, tag, attr, innerHtml)
+}
+
+// RenderTagWithMap renders a tag with the options of the renderer, and returns the spans of bytes in the html
+// that came from each part of the tag. See the RenderTagWithMap function.
+func (r *Renderer) RenderTagWithMap(tag string, attr Attributes, innerHtml string) (html string, spans []SourceSpan) {
+	return renderTagWithMap(r, tag, attr, innerHtml)
+}
+
+// renderTagWithMap renders a tag with writeTag, and works out the spans from the offsets of what was written.
+func renderTagWithMap(r *Renderer, tag string, attr Attributes, innerHtml string) (html string, spans []SourceSpan) {
+	// apply the options here, so that the attribute spans are of the attributes that are written
+	attr = r.apply(tag, attr)
+	b := strings.Builder{}
+	w := &offsetWriter{w: &b}
+	isVoid := voidTags[tag]
+	var content *markedWriterTo
+	var inner io.WriterTo
+	if innerHtml != "" && !isVoid {
+		content = &markedWriterTo{inner: strings.NewReader(innerHtml), w: w}
+		inner = content
+	}
+	if _, err := writeTag(nil, w, tag, attr, inner, isVoid, WhitespaceNewline, false); err != nil {
+		panic(err)
+	}
+	html = b.String()
+
+	start := len("<" + tag)
+	openEnd := start + 1
+	var attrSpans []SourceSpan
+	if attr.isRendered() {
+		for _, k := range attr.renderedKeys() {
+			start++ // the space before the attribute
+			end := start + kvLen(k, attr[k])
+			attrSpans = append(attrSpans, SourceSpan{Start: start, End: end, Kind: SpanAttribute, Tag: tag, Attribute: k})
+			start = end
+		}
+		openEnd = start + 1
+	}
+
+	spans = append(spans, SourceSpan{Start: 0, End: len(html), Kind: SpanElement, Tag: tag})
+	spans = append(spans, SourceSpan{Start: 0, End: openEnd, Kind: SpanOpenTag, Tag: tag})
+	spans = append(spans, attrSpans...)
+	if content != nil {
+		spans = append(spans, SourceSpan{Start: content.start, End: content.end, Kind: SpanContent, Tag: tag})
+	}
+	if !isVoid {
+		spans = append(spans, SourceSpan{Start: len(html) - len("</"+tag+">"), End: len(html), Kind: SpanCloseTag, Tag: tag})
+	}
+	return
+}
+
+// offsetWriter is a writer that keeps track of the number of bytes that have been written to it.
+type offsetWriter struct {
+	w      io.Writer
+	offset int
+}
+
+func (o *offsetWriter) Write(p []byte) (n int, err error) {
+	n, err = o.w.Write(p)
+	o.offset += n
+	return
+}
+
+// markedWriterTo is an io.WriterTo that records the offsets of w before and after inner is written.
+type markedWriterTo struct {
+	inner io.WriterTo
+	w     *offsetWriter
+	start int
+	end   int
+}
+
+// WriteTo implements the io.WriterTo interface.
+func (m *markedWriterTo) WriteTo(w io.Writer) (n int64, err error) {
+	m.start = m.w.offset
+	n, err = m.inner.WriteTo(w)
+	m.end = m.w.offset
+	return
+}
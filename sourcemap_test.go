@@ -0,0 +1,75 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleRenderTagWithMap() {
+	html, spans := RenderTagWithMap("p", Attributes{"id": "a", "class": "b"}, "Hi")
+	for _, s := range spans {
+		fmt.Println(strings.TrimSpace(fmt.Sprintf("%d %q %s", s.Kind, html[s.Start:s.End], s.Attribute)))
+	}
+	// Output:
+	// 0 "<p id=\"a\" class=\"b\">\nHi\n</p>"
+	// 1 "<p id=\"a\" class=\"b\">"
+	// 2 "id=\"a\"" id
+	// 2 "class=\"b\"" class
+	// 3 "Hi"
+	// 4 "</p>"
+}
+
+func TestRenderTagWithMap(t *testing.T) {
+	tests := []struct {
+		tag   string
+		attr  Attributes
+		inner string
+		spans int
+	}{
+		{"div", nil, "", 3},
+		{"div", Attributes{"disabled": "", "title": "a&b"}, "<b>x</b>", 6},
+		{"template", nil, "<b>x</b>", 4},
+		{"div", Attributes{"hidden": FalseValue, "id": "a"}, "", 4},
+		{"br", nil, "x", 2},
+		{"img", Attributes{"src": "a.png", "alt": ""}, "", 4},
+	}
+	for _, tt := range tests {
+		html, spans := RenderTagWithMap(tt.tag, tt.attr, tt.inner)
+		want := RenderTag(tt.tag, tt.attr, tt.inner)
+		if voidTags[tt.tag] {
+			want = RenderVoidTag(tt.tag, tt.attr)
+		}
+		if html != want {
+			t.Errorf("RenderTagWithMap() = %q, want %q", html, want)
+		}
+		if len(spans) != tt.spans {
+			t.Errorf("RenderTagWithMap() returned %d spans, want %d", len(spans), tt.spans)
+		}
+		for _, s := range spans {
+			if s.Kind == SpanAttribute && html[s.Start:s.End] != (Attributes{s.Attribute: tt.attr[s.Attribute]}).String() {
+				t.Errorf("attribute span %v does not cover its attribute", s)
+			}
+			if s.Kind == SpanOpenTag && !strings.HasSuffix(html[s.Start:s.End], ">") {
+				t.Errorf("open tag span %v does not end the tag", s)
+			}
+			if s.Kind == SpanCloseTag && html[s.Start:s.End] != "</"+tt.tag+">" {
+				t.Errorf("close tag span %v does not cover the close tag", s)
+			}
+		}
+	}
+}
+
+func TestRenderer_RenderTagWithMap(t *testing.T) {
+	r := &Renderer{OmitDefaults: true, Hooks: []RenderHook{func(tag string, attr Attributes) Attributes {
+		attr.Set("id", "b")
+		return attr
+	}}}
+	html, spans := r.RenderTagWithMap("input", Attributes{"type": "text"}, "")
+	if html != `<input id="b">` {
+		t.Errorf("RenderTagWithMap() = %q", html)
+	}
+	if len(spans) != 3 || spans[2].Attribute != "id" || html[spans[2].Start:spans[2].End] != `id="b"` {
+		t.Errorf("RenderTagWithMap() spans = %v", spans)
+	}
+}
@@ -23,6 +23,7 @@ var nonLengthNumerics = map[string]bool{
 	"widows":            true,
 	"pitch-range":       true,
 	"font-weight":       true,
+	"line-height":       true,
 	"z-index":           true,
 	"counter-increment": true,
 	"counter-reset":     true,
@@ -49,10 +50,30 @@ func (s Style) Copy() Style {
 // Merge merges the styles from one style to another. Conflicts will overwrite the current style.
 func (s Style) Merge(m Style) {
 	for k, v := range m {
+		s[normalizeProperty(k)] = v
+	}
+}
+
+// MergeRespectingImportant merges the styles from other into the style, following the css cascade rules for
+// !important. A value in other that is not !important will not replace a current value that is !important.
+// Otherwise, the value in other wins.
+func (s Style) MergeRespectingImportant(other Style) {
+	for k, v := range other {
+		k = normalizeProperty(k)
+		if cur, ok := s[k]; ok && isImportant(cur) && !isImportant(v) {
+			continue
+		}
 		s[k] = v
 	}
 }
 
+// isImportant returns true if the style value has an !important priority.
+func isImportant(v string) bool {
+	v = strings.TrimSpace(v)
+	i := strings.LastIndexByte(v, '!')
+	return i != -1 && strings.EqualFold(strings.TrimSpace(v[i+1:]), "important")
+}
+
 // Len returns the number of properties in the style.
 func (s Style) Len() int {
 	if s == nil {
@@ -66,33 +87,39 @@ func (s Style) Has(property string) bool {
 	if s == nil {
 		return false
 	}
-	_, ok := s[property]
+	_, ok := s[normalizeProperty(property)]
 	return ok
 }
 
 // Get returns the property.
 func (s Style) Get(property string) string {
-	return s[property]
+	return s[normalizeProperty(property)]
 }
 
 // Remove removes the property.
 func (s Style) Remove(property string) {
-	delete(s, property)
+	delete(s, normalizeProperty(property))
+}
+
+// normalizeProperty trims the property name, and lower cases it unless it is a custom property.
+// Standard css property names are case-insensitive, but custom property names are case-sensitive.
+func normalizeProperty(property string) string {
+	property = strings.TrimSpace(property)
+	if strings.HasPrefix(property, "--") {
+		return property
+	}
+	return strings.ToLower(property)
 }
 
 // SetString receives a style encoded "style" attribute into the Style structure (e.g. "width: 4px; border: 1px solid black")
 func (s Style) SetString(text string) (changed bool, err error) {
 	s.RemoveAll()
-	a := strings.Split(text, ";") // break apart into pairs
-	changed = false
-	err = nil
-	for _, value := range a {
-		b := strings.Split(value, ":")
-		if len(b) != 2 {
-			err = errors.New("Css must be a name/value pair separated by a colon. '" + string(text) + "' was given.")
-			return
-		}
-		newChange, newErr := s.SetChanged(strings.TrimSpace(b[0]), strings.TrimSpace(b[1]))
+	decls, err := SplitDeclarations(text)
+	if err != nil {
+		return
+	}
+	for _, d := range decls {
+		newChange, newErr := s.SetChanged(d.Property, d.Value)
 		if newErr != nil {
 			err = newErr
 			return
@@ -109,7 +136,11 @@ func (s Style) SetString(text string) (changed bool, err error) {
 // For example, Set ("height", "* 2") will double the height value without changing the unit specifier
 // When referring to a value that can be a length, you can use numeric values. In this case, "0" will be passed unchanged,
 // but any other number will automatically get a "px" suffix.
+//
+// The property name is trimmed, and is lower cased unless it is a custom property that starts with "--",
+// so that "Color" and "color" are treated as the same property.
 func (s Style) SetChanged(property string, value string) (changed bool, err error) {
+	property = normalizeProperty(property)
 	if strings.Contains(property, " ") {
 		err = errors.New("attribute names cannot contain spaces")
 		return
@@ -150,6 +181,78 @@ func (s Style) Set(property string, value string) Style {
 	return s
 }
 
+// SetDefault sets the property to the given value only if the property is not already set, and returns true
+// if it was set. The value is processed the same way as in SetChanged. Use it to apply base styles without
+// changing styles that were already chosen.
+func (s Style) SetDefault(property, value string) bool {
+	if s.Has(property) {
+		return false
+	}
+	_, err := s.SetChanged(property, value)
+	return err == nil
+}
+
+// borderStyles are the keywords allowed as a border style.
+var borderStyles = map[string]bool{
+	"none":   true,
+	"hidden": true,
+	"dotted": true,
+	"dashed": true,
+	"solid":  true,
+	"double": true,
+	"groove": true,
+	"ridge":  true,
+	"inset":  true,
+	"outset": true,
+}
+
+// SetBorder sets the border shorthand property from its parts. A width that is a plain number gets a "px" suffix,
+// the same as in SetChanged, and a negative width is an error. style must be a css border style keyword,
+// like "solid", and color is used as is. Empty parts are left out of the shorthand.
+func (s Style) SetBorder(width, style, color string) error {
+	return s.setBorder("border", width, style, color)
+}
+
+// SetBorderSide is like SetBorder, but sets the border of one side, which must be "top", "right", "bottom" or "left".
+func (s Style) SetBorderSide(side, width, style, color string) error {
+	switch side {
+	case "top", "right", "bottom", "left":
+	default:
+		return fmt.Errorf("invalid border side %q", side)
+	}
+	return s.setBorder("border-"+side, width, style, color)
+}
+
+// borderWidthMatcher matches a border width that is a plain number, which needs at least one digit.
+var borderWidthMatcher = regexp.MustCompile(`^(\d+|\d*\.\d+)$`)
+
+func (s Style) setBorder(property, width, style, color string) error {
+	var parts []string
+	if width = strings.TrimSpace(width); width != "" {
+		if strings.HasPrefix(width, "-") {
+			return fmt.Errorf("border width %q cannot be negative", width)
+		}
+		if width != "0" && borderWidthMatcher.MatchString(width) {
+			width += "px"
+		}
+		parts = append(parts, width)
+	}
+	if style = strings.ToLower(strings.TrimSpace(style)); style != "" {
+		if !borderStyles[style] {
+			return fmt.Errorf("invalid border style %q", style)
+		}
+		parts = append(parts, style)
+	}
+	if color = strings.TrimSpace(color); color != "" {
+		parts = append(parts, color)
+	}
+	if parts == nil {
+		return errors.New("a border needs a width, style or color")
+	}
+	s.set(property, strings.Join(parts, " "))
+	return nil
+}
+
 // opReplacer is used in the regular expression replacement function below
 func opReplacer(op string, v float64) func(string) string {
 	return func(cur string) string {
@@ -198,6 +301,58 @@ func (s Style) mathOp(property string, op string, val string) (changed bool, err
 	return
 }
 
+// SetTokenMath is like a math operation in Set, but it only changes the numeric value of the space separated token
+// at tokenIndex in the current value of property. op is one of "+", "-", "*" or "/". For example, with a margin
+// of "4px 8px", SetTokenMath("margin", 1, "*", 2) will change the margin to "4px 16px".
+//
+// An error is returned if op is not valid or there is no token at tokenIndex.
+func (s Style) SetTokenMath(property string, tokenIndex int, op string, val float64) error {
+	if op != "+" && op != "-" && op != "*" && op != "/" {
+		return fmt.Errorf("invalid math operation %q", op)
+	}
+	cur := s.Get(property)
+	if cur == "" {
+		cur = "0"
+	}
+	tokens := strings.Fields(cur)
+	if tokenIndex < 0 || tokenIndex >= len(tokens) {
+		return fmt.Errorf("property %s has no token at index %d", property, tokenIndex)
+	}
+	tokens[tokenIndex] = numericReplacer.ReplaceAllStringFunc(tokens[tokenIndex], opReplacer(op, val))
+	s.set(property, strings.Join(tokens, " "))
+	return nil
+}
+
+var lengthSplitter = regexp.MustCompile(`^([-+]?(?:\d+|\d*\.\d+))([a-zA-Z]+|%)?$`)
+
+// SplitLength splits a single css value, like "12.5rem", into its number and its unit. A number without a unit,
+// like "0" or "1.5", returns an empty unit. ok is false if value is not a single number with an optional unit,
+// like "auto" or "4px 8px".
+func SplitLength(value string) (number float64, unit string, ok bool) {
+	m := lengthSplitter.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return
+	}
+	number, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return
+	}
+	return number, m[2], true
+}
+
+// MapValues replaces the value of each property with the value returned by f. Properties are visited in
+// alphabetical order. The value returned by f is stored as is, without the length processing that Set does.
+func (s Style) MapValues(f func(property, value string) string) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s[k] = f(k, s[k])
+	}
+}
+
 // RemoveAll resets the style to contain no styles
 func (s Style) RemoveAll() {
 	for k := range s {
@@ -210,8 +365,66 @@ func (s Style) String() string {
 	return s.encode()
 }
 
+// CSSText returns the style in the form used by the cssText property of the style of an element in javascript,
+// which is the same as the value of the style attribute.
+func (s Style) CSSText() string {
+	return s.encode()
+}
+
+// JSProperties returns the style as a map of the javascript property names of the style of an element to their
+// values, so that each can be assigned with element.style[name] = value. For example, "background-color"
+// becomes "backgroundColor", "-webkit-transition" becomes "WebkitTransition", and "float" becomes "cssFloat".
+// Custom properties, like "--main-color", keep their names, and must be set with element.style.setProperty.
+func (s Style) JSProperties() map[string]string {
+	m := make(map[string]string, len(s))
+	for k, v := range s {
+		m[jsPropertyName(k)] = v
+	}
+	return m
+}
+
+// CustomProperties returns the custom properties in the style, which are the css variables whose names
+// start with "--", mapped to their values. The returned map is a copy, and is empty if there are none.
+func (s Style) CustomProperties() map[string]string {
+	m := make(map[string]string)
+	for k, v := range s {
+		if strings.HasPrefix(k, "--") {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// jsPropertyName converts a css property name to the name of the property in javascript.
+func jsPropertyName(property string) string {
+	switch {
+	case strings.HasPrefix(property, "--"):
+		return property
+	case property == "float":
+		return "cssFloat"
+	case strings.HasPrefix(property, "-ms-"):
+		// Microsoft prefixes are the exception, and start with a lower case letter
+		property = property[1:]
+	}
+	parts := strings.Split(property, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// ApproxSize returns the approximate number of bytes of memory the style uses.
+//
+// See Attributes.ApproxSize.
+func (s Style) ApproxSize() int {
+	return approxMapSize(s)
+}
+
 // set is a raw set and return true if changed
 func (s Style) set(k string, v string) bool {
+	k = normalizeProperty(k)
 	oldVal, existed := s[k]
 	s[k] = v
 	return !existed || oldVal != v
@@ -241,18 +454,91 @@ func (s Style) encode() (text string) {
 		if i > 0 {
 			text += ";"
 		}
-		text += k + ":" + s.Get(k)
+		text += k + ":" + escapeCSSValue(s.Get(k))
 	}
 	return text
 }
 
+// escapeCSSValue escapes the quotes and new lines in a css value so that the value cannot change how
+// the declarations that follow it are read.
+//
+// Quoted strings, like the "\"" in content:"\"", are kept as given, except that new lines inside
+// them are written as css escapes. A quote that is not closed, like the one in content:", is escaped with a
+// backslash, since it would otherwise start a string that swallows the rest of the style.
+// A trailing backslash is escaped for the same reason.
+func escapeCSSValue(v string) string {
+	if !strings.ContainsAny(v, "\"'\\\n\r\f") {
+		return v
+	}
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch c {
+		case '\\':
+			if i+1 == len(v) {
+				b.WriteString(`\\`)
+			} else {
+				b.WriteByte(c)
+				i++
+				b.WriteByte(v[i])
+			}
+		case '"', '\'':
+			end := closingQuote(v, i)
+			if end == -1 {
+				b.WriteByte('\\')
+				b.WriteByte(c)
+				continue
+			}
+			b.WriteByte(c)
+			for i++; i < end; i++ {
+				switch v[i] {
+				case '\n':
+					b.WriteString(`\a `)
+				case '\r':
+					b.WriteString(`\d `)
+				case '\f':
+					b.WriteString(`\c `)
+				case '\\':
+					b.WriteByte('\\')
+					i++
+					b.WriteByte(v[i])
+				default:
+					b.WriteByte(v[i])
+				}
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// closingQuote returns the offset of the quote that closes the string that starts at offset start in v,
+// or -1 if the string is not closed.
+func closingQuote(v string, start int) int {
+	for i := start + 1; i < len(v); i++ {
+		switch v[i] {
+		case '\\':
+			i++
+		case v[start]:
+			return i
+		}
+	}
+	return -1
+}
+
 // StyleString converts an interface type that is being used to set a style value to a string that can be fed into
 // the SetStyle* functions
 func StyleString(i interface{}) string {
 	var sValue string
 	switch v := i.(type) {
 	case int:
-		sValue = fmt.Sprintf("%dpx", v)
+		sValue = strconv.Itoa(v) + "px"
+	case int64:
+		sValue = strconv.FormatInt(v, 10) + "px"
+	case uint:
+		sValue = strconv.FormatUint(uint64(v), 10) + "px"
 	case float32:
 		sValue = fmt.Sprintf("%gpx", v)
 	case float64:
@@ -277,3 +563,593 @@ func MergeStyleStrings(s1, s2 string) string {
 	style1.Merge(style2)
 	return style1.String()
 }
+
+// compactShorthands lists the box shorthand properties that StringCompact can rebuild from their longhands.
+// Each entry lists the longhands in top, right, bottom, left order.
+var compactShorthands = []struct {
+	shorthand string
+	longhands [4]string
+}{
+	{"margin", [4]string{"margin-top", "margin-right", "margin-bottom", "margin-left"}},
+	{"padding", [4]string{"padding-top", "padding-right", "padding-bottom", "padding-left"}},
+	{"border-width", [4]string{"border-top-width", "border-right-width", "border-bottom-width", "border-left-width"}},
+	{"border-style", [4]string{"border-top-style", "border-right-style", "border-bottom-style", "border-left-style"}},
+	{"border-color", [4]string{"border-top-color", "border-right-color", "border-bottom-color", "border-left-color"}},
+}
+
+// StringCompact is like String, but collapses groups of longhand properties into their shorthand to
+// produce a smaller style string. For example, margin-top, margin-right, margin-bottom and margin-left
+// become a single margin property.
+//
+// A group is only collapsed when all of its longhands are present, each has a single token value that is
+// not !important and not a css-wide keyword like inherit, and the shorthand itself is not also set, so the
+// result always means the same thing as the original.
+func (s Style) StringCompact() string {
+	s2 := s.Copy()
+	for _, c := range compactShorthands {
+		if s2.Has(c.shorthand) {
+			continue
+		}
+		var values [4]string
+		ok := true
+		for i, l := range c.longhands {
+			v, has := s2[l]
+			if !has || v == "" || strings.ContainsAny(v, " \t\n!") || cssWideKeywords[strings.ToLower(v)] {
+				ok = false
+				break
+			}
+			values[i] = v
+		}
+		if !ok {
+			continue
+		}
+		for _, l := range c.longhands {
+			delete(s2, l)
+		}
+		s2[c.shorthand] = boxShorthand(values)
+	}
+	return s2.encode()
+}
+
+// cssWideKeywords are the values that every css property accepts. They can only be used alone, so
+// a shorthand cannot be built from a longhand with one of these values.
+var cssWideKeywords = map[string]bool{
+	"inherit":      true,
+	"initial":      true,
+	"unset":        true,
+	"revert":       true,
+	"revert-layer": true,
+}
+
+// boxShorthand returns the shortest shorthand value that represents the given top, right, bottom and left values.
+func boxShorthand(v [4]string) string {
+	if v[1] == v[3] {
+		if v[0] == v[2] {
+			if v[0] == v[1] {
+				return v[0]
+			}
+			return v[0] + " " + v[1]
+		}
+		return v[0] + " " + v[1] + " " + v[2]
+	}
+	return strings.Join(v[:], " ")
+}
+
+// initialValues maps css properties to their initial value, as defined in the CSS specifications.
+//
+// Only properties that are not inherited are listed. Setting an inherited property, like color or letter-spacing,
+// to its initial value stops the element from inheriting the value of its parent, so it is not a default.
+// Properties that user agent style sheets commonly set on particular elements, like display, margin and padding,
+// are also left out, since setting those to their initial value is often meaningful.
+var initialValues = map[string]string{
+	"animation-delay":     "0s",
+	"animation-name":      "none",
+	"backface-visibility": "visible",
+	"box-shadow":          "none",
+	"clear":               "none",
+	"column-gap":          "normal",
+	"filter":              "none",
+	"flex-grow":           "0",
+	"flex-shrink":         "1",
+	"float":               "none",
+	"isolation":           "auto",
+	"mix-blend-mode":      "normal",
+	"opacity":             "1",
+	"order":               "0",
+	"transform":           "none",
+	"transition-delay":    "0s",
+	"will-change":         "auto",
+	"z-index":             "auto",
+}
+
+// DropDefaults removes the properties whose value is the initial value of the property as defined
+// by CSS, and so have no effect. For example, "opacity:1" and "float:none" are removed.
+//
+// This is conservative, and only knows about properties that are not inherited, and whose initial value is not
+// commonly changed by the browser's default style sheet. It does not take into account values set in a style sheet,
+// which a style attribute would override.
+func (s Style) DropDefaults() {
+	for k, v := range s {
+		if iv, ok := initialValues[strings.ToLower(k)]; ok && strings.ToLower(v) == iv {
+			delete(s, k)
+		}
+	}
+}
+
+// GetComputed returns the value of the given property. If the property is not set directly, but a shorthand that
+// includes it is, the value is extracted from the shorthand. For example, if "font" is set to
+// "italic bold 16px/1.5 sans-serif", GetComputed("line-height") returns "1.5".
+//
+// Currently, the font shorthand and the box shorthands margin, padding, border-width, border-style and border-color
+// are understood. Returns an empty string if the value cannot be determined. The property name is normalized
+// the same way as in Get.
+func (s Style) GetComputed(property string) string {
+	property = normalizeProperty(property)
+	if v, ok := s[property]; ok {
+		return v
+	}
+	if strings.HasPrefix(property, "font-") || property == "line-height" {
+		if f, ok := s["font"]; ok {
+			return parseFontShorthand(f)[property]
+		}
+		return ""
+	}
+	for _, c := range compactShorthands {
+		for i, l := range c.longhands {
+			if l == property {
+				if v, ok := s[c.shorthand]; ok {
+					return boxLonghand(v, i)
+				}
+				return ""
+			}
+		}
+	}
+	return ""
+}
+
+// boxLonghand returns the value for the given side of a box shorthand value, where
+// the sides are numbered top, right, bottom, left.
+func boxLonghand(v string, side int) string {
+	f := strings.Fields(v)
+	switch len(f) {
+	case 1:
+		return f[0]
+	case 2:
+		return f[side%2]
+	case 3:
+		if side == 3 {
+			return f[1]
+		}
+		return f[side]
+	case 4:
+		return f[side]
+	}
+	return ""
+}
+
+var fontStyleKeywords = map[string]bool{"italic": true, "oblique": true}
+var fontVariantKeywords = map[string]bool{"small-caps": true}
+var fontWeightKeywords = map[string]bool{"bold": true, "bolder": true, "lighter": true,
+	"100": true, "200": true, "300": true, "400": true, "500": true, "600": true, "700": true, "800": true, "900": true}
+var fontStretchKeywords = map[string]bool{"ultra-condensed": true, "extra-condensed": true, "condensed": true,
+	"semi-condensed": true, "semi-expanded": true, "expanded": true, "extra-expanded": true, "ultra-expanded": true}
+var fontSizeKeywords = map[string]bool{"xx-small": true, "x-small": true, "small": true, "medium": true, "large": true,
+	"x-large": true, "xx-large": true, "xxx-large": true, "larger": true, "smaller": true}
+
+var lengthMatcher = regexp.MustCompile(`^-?(\d+|\d*\.\d+)([a-zA-Z]+|%)$`)
+
+// parseFontShorthand splits the value of a font shorthand property into its longhand properties.
+// Returns nil if the value is a system font keyword or cannot be parsed.
+func parseFontShorthand(v string) map[string]string {
+	ret := map[string]string{
+		"font-style":   "normal",
+		"font-variant": "normal",
+		"font-weight":  "normal",
+		"font-stretch": "normal",
+		"line-height":  "normal",
+	}
+	i := 0
+	next := func() string {
+		for i < len(v) && v[i] == ' ' {
+			i++
+		}
+		start := i
+		for i < len(v) && v[i] != ' ' && (v[i] != '/' || i == start) {
+			i++
+		}
+		return v[start:i]
+	}
+	for {
+		tok := next()
+		if tok == "" {
+			return nil
+		}
+		lower := strings.ToLower(tok)
+		switch {
+		case lower == "normal":
+		case fontStyleKeywords[lower]:
+			ret["font-style"] = tok
+		case fontVariantKeywords[lower]:
+			ret["font-variant"] = tok
+		case fontWeightKeywords[lower]:
+			ret["font-weight"] = tok
+		case fontStretchKeywords[lower]:
+			ret["font-stretch"] = tok
+		case fontSizeKeywords[lower] || lengthMatcher.MatchString(tok):
+			ret["font-size"] = tok
+			// look for an optional line height after a slash
+			save := i
+			if lh := next(); strings.HasPrefix(lh, "/") {
+				if lh == "/" {
+					lh = next()
+				} else {
+					lh = lh[1:]
+				}
+				if lh == "" {
+					return nil
+				}
+				ret["line-height"] = lh
+			} else {
+				i = save
+			}
+			family := strings.TrimSpace(v[i:])
+			if family == "" {
+				return nil
+			}
+			ret["font-family"] = family
+			return ret
+		default:
+			return nil
+		}
+	}
+}
+
+// Declaration is a single css property and its value.
+type Declaration struct {
+	Property string
+	Value    string
+}
+
+// SplitDeclarations splits css text, like the value of a style attribute, into its declarations.
+//
+// Declarations are separated by semicolons, and the property is separated from the value by the first colon.
+// Semicolons and colons inside of quotes or parentheses, like in url("http://a.com/b;c"), are treated as
+// part of the value. Empty declarations are skipped. Properties and values are trimmed of surrounding space.
+// Returns an error if a declaration has no colon or no property, or if a quote or parenthesis is not closed.
+func SplitDeclarations(css string) (decls []Declaration, err error) {
+	var quote byte
+	var parens int
+	start := 0
+	colon := -1
+	for i := 0; i <= len(css); i++ {
+		if i < len(css) {
+			c := css[i]
+			if quote != 0 {
+				if c == '\\' && i+1 < len(css) {
+					i++
+				} else if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			switch c {
+			case '\\':
+				if i+1 < len(css) {
+					i++
+				}
+				continue
+			case '"', '\'':
+				quote = c
+				continue
+			case '(':
+				parens++
+				continue
+			case ')':
+				if parens > 0 {
+					parens--
+				}
+				continue
+			case ':':
+				if parens == 0 && colon == -1 {
+					colon = i
+				}
+				continue
+			case ';':
+				if parens > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		} else if quote != 0 || parens > 0 {
+			err = fmt.Errorf("unterminated quote or parenthesis in css '%s'", css)
+			return
+		}
+		// at the end of a declaration
+		d := css[start:i]
+		if strings.TrimSpace(d) != "" {
+			if colon == -1 {
+				err = errors.New("Css must be a name/value pair separated by a colon. '" + css + "' was given.")
+				return
+			}
+			p := strings.TrimSpace(css[start:colon])
+			if p == "" {
+				err = errors.New("Css is missing a property name. '" + css + "' was given.")
+				return
+			}
+			decls = append(decls, Declaration{p, strings.TrimSpace(css[colon+1 : i])})
+		}
+		start = i + 1
+		colon = -1
+	}
+	return
+}
+
+// responsiveViewports gives the viewport widths, in the given unit, between which SetResponsive scales a value.
+// rem and em values assume the browser default of 16px per em.
+var responsiveViewports = map[string][2]float64{
+	"px":  {320, 1280},
+	"rem": {20, 80},
+	"em":  {20, 80},
+}
+
+// lengthProperties are properties that accept a single length value.
+var lengthProperties = map[string]bool{
+	"font-size": true, "line-height": true, "letter-spacing": true, "word-spacing": true, "text-indent": true,
+	"width": true, "height": true, "min-width": true, "min-height": true, "max-width": true, "max-height": true,
+	"margin": true, "margin-top": true, "margin-right": true, "margin-bottom": true, "margin-left": true,
+	"padding": true, "padding-top": true, "padding-right": true, "padding-bottom": true, "padding-left": true,
+	"top": true, "right": true, "bottom": true, "left": true, "inset": true,
+	"gap": true, "row-gap": true, "column-gap": true, "flex-basis": true,
+	"border-width": true, "border-radius": true, "outline-width": true, "outline-offset": true,
+}
+
+// SetResponsive sets the property to a value that scales smoothly with the width of the viewport, from min at
+// narrow viewports to max at wide viewports, using a css clamp() expression. For example:
+//
+//	s.SetResponsive("font-size", 1, 2, "rem")
+//
+// sets font-size to "clamp(1rem, 0.6667rem + 1.6667vw, 2rem)", which is 1rem on viewports 20rem (320px) wide or
+// less, 2rem on viewports 80rem (1280px) wide or more, and scales linearly in between.
+//
+// unit must be "px", "rem" or "em". Returns an error if the property does not take a length, or if min is greater than max.
+// Custom properties, which start with "--", are allowed.
+func (s Style) SetResponsive(property string, min, max float64, unit string) error {
+	if !lengthProperties[property] && !strings.HasPrefix(property, "--") {
+		return fmt.Errorf("%s is not a length property", property)
+	}
+	vp, ok := responsiveViewports[unit]
+	if !ok {
+		return fmt.Errorf("%s is not a supported unit", unit)
+	}
+	if min > max {
+		return fmt.Errorf("min %g is greater than max %g", min, max)
+	}
+	if min == max {
+		s.set(property, formatFloat(min)+unit)
+		return nil
+	}
+	slope := (max - min) / (vp[1] - vp[0])
+	intercept := min - slope*vp[0]
+	preferred := formatFloat(roundFloat(slope*100, 4)) + "vw"
+	if i := roundFloat(intercept, 4); i < 0 {
+		preferred += " - " + formatFloat(-i) + unit
+	} else if i > 0 {
+		preferred = formatFloat(i) + unit + " + " + preferred
+	}
+	s.set(property, "clamp("+formatFloat(min)+unit+", "+preferred+", "+formatFloat(max)+unit+")")
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// RemoveAnimations removes the transition and animation properties, including their longhands and
+// vendor prefixed versions, so that an element will not animate. It returns the names of the properties
+// that were removed, in alphabetical order, so that they can be restored later.
+func (s Style) RemoveAnimations() (removed []string) {
+	for k := range s {
+		p := k
+		if strings.HasPrefix(p, "--") {
+			continue // custom properties can have any name
+		}
+		if strings.HasPrefix(p, "-") {
+			// remove a vendor prefix, like -webkit-
+			if i := strings.Index(p[1:], "-"); i != -1 {
+				p = p[i+2:]
+			}
+		}
+		if strings.HasPrefix(p, "transition") || strings.HasPrefix(p, "animation") {
+			removed = append(removed, k)
+			delete(s, k)
+		}
+	}
+	sort.Strings(removed)
+	return
+}
+
+// TransformFunc is one of the functions in the value of a css transform property, like translate(10px, 20px).
+type TransformFunc struct {
+	// Name is the name of the function, like "translate" or "rotate".
+	Name string
+	// Args are the arguments of the function, like "10px" and "20px".
+	Args []string
+}
+
+// String returns the function as it appears in a transform value.
+func (f TransformFunc) String() string {
+	return f.Name + "(" + strings.Join(f.Args, ", ") + ")"
+}
+
+// GetTransforms returns the functions in the transform property, in order. Arguments are split at the commas
+// that are not inside nested parentheses, so an argument like calc(10px + 2%) or var(--x, 1px) is kept whole.
+//
+// Returns nil if there is no transform property, if it is "none", or if it cannot be parsed.
+func (s Style) GetTransforms() []TransformFunc {
+	v := strings.TrimSpace(s.Get("transform"))
+	if v == "" || v == "none" {
+		return nil
+	}
+	var funcs []TransformFunc
+	for v != "" {
+		open := strings.IndexByte(v, '(')
+		if open < 1 {
+			return nil
+		}
+		f := TransformFunc{Name: strings.TrimSpace(v[:open])}
+		if strings.ContainsAny(f.Name, " \t\n,)") {
+			return nil
+		}
+		depth := 0
+		start := open + 1
+		end := -1
+		for i := start; i < len(v) && end == -1; i++ {
+			switch v[i] {
+			case '(':
+				depth++
+			case ')':
+				if depth == 0 {
+					end = i
+				} else {
+					depth--
+				}
+			case ',':
+				if depth == 0 {
+					f.Args = append(f.Args, strings.TrimSpace(v[start:i]))
+					start = i + 1
+				}
+			}
+		}
+		if end == -1 {
+			return nil
+		}
+		if arg := strings.TrimSpace(v[start:end]); arg != "" || len(f.Args) > 0 {
+			f.Args = append(f.Args, arg)
+		}
+		funcs = append(funcs, f)
+		v = strings.TrimSpace(v[end+1:])
+	}
+	return funcs
+}
+
+// SetTransforms sets the transform property to the given functions, in order, replacing the current transform.
+// If funcs is empty, the transform property is removed.
+//
+// Use it with GetTransforms to change one function of a transform without changing the others. For example,
+// to add a scale to an existing transform:
+//
+//	s.SetTransforms(append(s.GetTransforms(), TransformFunc{"scale", []string{"1.5"}}))
+func (s Style) SetTransforms(funcs []TransformFunc) Style {
+	if len(funcs) == 0 {
+		s.Remove("transform")
+		return s
+	}
+	parts := make([]string, len(funcs))
+	for i, f := range funcs {
+		parts[i] = f.String()
+	}
+	s.set("transform", strings.Join(parts, " "))
+	return s
+}
+
+// GridColumns returns the column tracks of a grid, from the grid-template-columns property, or from the
+// grid-template shorthand if that is not set. See GridRows.
+func (s Style) GridColumns() []string {
+	if v, ok := s["grid-template-columns"]; ok {
+		return gridTracks(v)
+	}
+	if _, columns, ok := splitGridTemplate(s.Get("grid-template")); ok {
+		return gridTracks(columns)
+	}
+	return nil
+}
+
+// GridRows returns the row tracks of a grid, from the grid-template-rows property, or from the
+// grid-template shorthand if that is not set.
+//
+// Each track is returned as its own item. repeat() with a count is expanded, so "repeat(2, 1fr 2fr) auto" returns
+// 1fr, 2fr, 1fr, 2fr and auto. Functions like minmax(100px, 1fr) are kept whole, as is repeat() with auto-fill
+// or auto-fit, since the number of tracks depends on the size of the grid. Line names, like [main-start], and the
+// area strings of the grid-template shorthand are left out. Returns nil if there is no template or it is "none".
+func (s Style) GridRows() []string {
+	if v, ok := s["grid-template-rows"]; ok {
+		return gridTracks(v)
+	}
+	if rows, _, ok := splitGridTemplate(s.Get("grid-template")); ok {
+		return gridTracks(rows)
+	}
+	return nil
+}
+
+// splitGridTemplate splits a grid-template value at the slash between the rows and the columns.
+func splitGridTemplate(v string) (rows, columns string, ok bool) {
+	parts := splitTopLevel(v, '/')
+	if len(parts) != 2 {
+		return
+	}
+	return parts[0], parts[1], true
+}
+
+// gridTracks returns the tracks in a track list.
+func gridTracks(v string) (tracks []string) {
+	for _, t := range splitTopLevel(v, ' ') {
+		switch {
+		case t == "none":
+			continue
+		case strings.HasPrefix(t, "[") || strings.HasPrefix(t, `"`) || strings.HasPrefix(t, "'"):
+			// line names and area strings
+			continue
+		case strings.HasPrefix(strings.ToLower(t), "repeat(") && strings.HasSuffix(t, ")"):
+			args := splitTopLevel(t[len("repeat("):len(t)-1], ',')
+			if len(args) == 2 {
+				if count, err := strconv.Atoi(args[0]); err == nil && count > 0 {
+					inner := gridTracks(args[1])
+					for i := 0; i < count; i++ {
+						tracks = append(tracks, inner...)
+					}
+					continue
+				}
+			}
+			tracks = append(tracks, t)
+		default:
+			tracks = append(tracks, t)
+		}
+	}
+	return
+}
+
+// splitTopLevel splits v at each sep that is not inside parentheses, brackets or quotes, and returns the trimmed,
+// non-empty parts. If sep is a space, any white space separates the parts.
+func splitTopLevel(v string, sep byte) (parts []string) {
+	var depth int
+	var quote byte
+	start := 0
+	add := func(end int) {
+		if p := strings.TrimSpace(v[start:end]); p != "" {
+			parts = append(parts, p)
+		}
+		start = end + 1
+	}
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			if depth > 0 {
+				depth--
+			}
+		case depth == 0 && (c == sep || sep == ' ' && strings.IndexByte(" \t\n\r\f", c) != -1):
+			add(i)
+		}
+	}
+	add(len(v))
+	return
+}
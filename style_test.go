@@ -2,6 +2,7 @@ package html5tag
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -270,6 +271,8 @@ func TestStyleString(t *testing.T) {
 		want string
 	}{
 		{"int", int(5), "5px"},
+		{"int64", int64(-9223372036854775808), "-9223372036854775808px"},
+		{"uint", uint(18446744073709551615), "18446744073709551615px"},
 		{"float", float32(5.1), "5.1px"},
 		{"double", float64(5.2), "5.2px"},
 		{"string", "9em", "9em"},
@@ -285,3 +288,597 @@ func TestStyleString(t *testing.T) {
 		})
 	}
 }
+
+func TestStyle_StringQuotes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "red", `content:red`},
+		{"single quoted", `'"'`, `content:'"'`},
+		{"double quoted", `"\""`, `content:"\""`},
+		{"url", `url("a.png")`, `content:url("a.png")`},
+		{"unclosed", `"`, `content:\"`},
+		{"unclosed after string", `"a" "b`, `content:"a" \"b`},
+		{"newline in string", "\"a\nb\"", `content:"a\a b"`},
+		{"trailing backslash", `a\`, `content:a\\`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Style{"content": tt.value}
+			if got := s.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+			s2 := NewStyle()
+			if _, err := s2.SetString(s.String() + ";color:red"); err != nil {
+				t.Errorf("SetString() error = %v", err)
+			} else if s2.Get("color") != "red" {
+				t.Errorf("String() changed the following declaration: %v", s2)
+			}
+		})
+	}
+}
+
+func TestAttributes_StyleQuotes(t *testing.T) {
+	a := Attributes{}
+	a.SetStyle("content", `"`)
+	a.SetStyle("color", "red")
+	if got := a.String(); got != `style="color:red;content:\&#34;"` {
+		t.Errorf("String() = %v", got)
+	}
+	if a.GetStyle("color") != "red" {
+		t.Errorf("GetStyle() = %v", a.GetStyle("color"))
+	}
+}
+
+func ExampleStyle_StringCompact() {
+	s := Style{"margin-top": "1px", "margin-right": "2px", "margin-bottom": "1px", "margin-left": "2px", "color": "red"}
+	fmt.Println(s.StringCompact())
+	// Output: color:red;margin:1px 2px
+}
+
+func TestStyle_StringCompact(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Style
+		want string
+	}{
+		{"all same", Style{"padding-top": "1px", "padding-right": "1px", "padding-bottom": "1px", "padding-left": "1px"}, "padding:1px"},
+		{"three values", Style{"padding-top": "1px", "padding-right": "2px", "padding-bottom": "3px", "padding-left": "2px"}, "padding:1px 2px 3px"},
+		{"four values", Style{"margin-top": "1px", "margin-right": "2px", "margin-bottom": "3px", "margin-left": "4px"}, "margin:1px 2px 3px 4px"},
+		{"partial", Style{"margin-top": "1px", "margin-right": "2px", "margin-bottom": "3px"}, "margin-bottom:3px;margin-right:2px;margin-top:1px"},
+		{"important", Style{"margin-top": "1px !important", "margin-right": "1px", "margin-bottom": "1px", "margin-left": "1px"}, "margin-bottom:1px;margin-left:1px;margin-right:1px;margin-top:1px !important"},
+		{"shorthand present", Style{"margin": "0", "margin-top": "1px", "margin-right": "1px", "margin-bottom": "1px", "margin-left": "1px"}, "margin:0;margin-bottom:1px;margin-left:1px;margin-right:1px;margin-top:1px"},
+		{"border", Style{"border-top-style": "solid", "border-right-style": "solid", "border-bottom-style": "solid", "border-left-style": "solid"}, "border-style:solid"},
+		{"inherit", Style{"margin-top": "inherit", "margin-right": "0", "margin-bottom": "0", "margin-left": "0"}, "margin-bottom:0;margin-left:0;margin-right:0;margin-top:inherit"},
+		{"all initial", Style{"padding-top": "initial", "padding-right": "initial", "padding-bottom": "initial", "padding-left": "Initial"}, "padding-bottom:initial;padding-left:Initial;padding-right:initial;padding-top:initial"},
+		{"unset", Style{"border-top-color": "red", "border-right-color": "unset", "border-bottom-color": "red", "border-left-color": "red"}, "border-bottom-color:red;border-left-color:red;border-right-color:unset;border-top-color:red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.StringCompact(); got != tt.want {
+				t.Errorf("StringCompact() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleStyle_DropDefaults() {
+	s := Style{"opacity": "1", "float": "left", "z-index": "auto", "margin": "0", "transform": "None"}
+	s.DropDefaults()
+	fmt.Println(s)
+	// Output: float:left;margin:0
+}
+
+func TestStyle_DropDefaults(t *testing.T) {
+	// inherited properties set to their initial value reset what they would inherit, so they are kept
+	s := Style{"color": "initial", "letter-spacing": "normal", "text-transform": "none", "visibility": "visible", "font-variant": "normal", "clear": "none"}
+	s.DropDefaults()
+	if got := s.String(); got != "color:initial;font-variant:normal;letter-spacing:normal;text-transform:none;visibility:visible" {
+		t.Errorf("DropDefaults() = %s", got)
+	}
+}
+
+func ExampleStyle_MapValues() {
+	s := Style{"color": "red", "background-color": "red", "width": "4px"}
+	s.MapValues(func(property, value string) string {
+		if value == "red" {
+			return "var(--brand)"
+		}
+		return value
+	})
+	fmt.Println(s)
+	// Output: background-color:var(--brand);color:var(--brand);width:4px
+}
+
+func ExampleStyle_GetComputed() {
+	s := Style{"font": `italic bold 16px/1.5 "Helvetica Neue", sans-serif`, "margin": "1px 2px"}
+	fmt.Println(s.GetComputed("font-size"))
+	fmt.Println(s.GetComputed("line-height"))
+	fmt.Println(s.GetComputed("font-family"))
+	fmt.Println(s.GetComputed("margin-left"))
+	// Output: 16px
+	// 1.5
+	// "Helvetica Neue", sans-serif
+	// 2px
+}
+
+func TestStyle_GetComputedFont(t *testing.T) {
+	tests := []struct {
+		font     string
+		property string
+		want     string
+	}{
+		{"12px serif", "font-size", "12px"},
+		{"12px serif", "line-height", "normal"},
+		{"12px serif", "font-weight", "normal"},
+		{"italic small-caps 700 condensed 2em / 120% Arial", "font-style", "italic"},
+		{"italic small-caps 700 condensed 2em / 120% Arial", "font-variant", "small-caps"},
+		{"italic small-caps 700 condensed 2em / 120% Arial", "font-weight", "700"},
+		{"italic small-caps 700 condensed 2em / 120% Arial", "font-stretch", "condensed"},
+		{"italic small-caps 700 condensed 2em / 120% Arial", "line-height", "120%"},
+		{"italic small-caps 700 condensed 2em / 120% Arial", "font-family", "Arial"},
+		{"normal bold large /2 monospace", "line-height", "2"},
+		{"normal bold large /2 monospace", "font-size", "large"},
+		{"caption", "font-size", ""},
+		{"16px", "font-size", ""},
+		{"bold 16px/ serif", "font-size", ""},
+	}
+	for _, tt := range tests {
+		s := Style{"font": tt.font}
+		if got := s.GetComputed(tt.property); got != tt.want {
+			t.Errorf("GetComputed(%q) of %q = %q, want %q", tt.property, tt.font, got, tt.want)
+		}
+	}
+	s := Style{"font": "bold 12px serif", "font-size": "14px"}
+	if got := s.GetComputed("font-size"); got != "14px" {
+		t.Errorf("longhand should win, got %q", got)
+	}
+	if got, want := s.GetComputed("Font-Size"), s.Get("Font-Size"); got != want {
+		t.Errorf("GetComputed() = %q, but Get() = %q", got, want)
+	}
+	if got := s.GetComputed("color"); got != "" {
+		t.Errorf("GetComputed() of missing = %q", got)
+	}
+}
+
+func TestStyle_GetComputedBox(t *testing.T) {
+	s := Style{"padding": "1px 2px 3px", "border-width": "1px 2px 3px 4px", "border-style": "solid"}
+	tests := []struct{ property, want string }{
+		{"padding-top", "1px"}, {"padding-right", "2px"}, {"padding-bottom", "3px"}, {"padding-left", "2px"},
+		{"border-left-width", "4px"}, {"border-top-style", "solid"}, {"margin-top", ""},
+		{"Padding-Top", "1px"}, {" BORDER-STYLE ", "solid"},
+	}
+	for _, tt := range tests {
+		if got := s.GetComputed(tt.property); got != tt.want {
+			t.Errorf("GetComputed(%q) = %q, want %q", tt.property, got, tt.want)
+		}
+	}
+}
+
+func ExampleSplitDeclarations() {
+	decls, _ := SplitDeclarations(`background: url("http://a.com/b;c") no-repeat; content: ':;'; color:red;`)
+	for _, d := range decls {
+		fmt.Println(d.Property, "=", d.Value)
+	}
+	// Output: background = url("http://a.com/b;c") no-repeat
+	// content = ':;'
+	// color = red
+}
+
+func TestSplitDeclarations(t *testing.T) {
+	tests := []struct {
+		name    string
+		css     string
+		want    []Declaration
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"simple", "a:b", []Declaration{{"a", "b"}}, false},
+		{"extra semicolons", " ; a : b ;; ", []Declaration{{"a", "b"}}, false},
+		{"unquoted url", "background-image:url(http://a.com/b;c)", []Declaration{{"background-image", "url(http://a.com/b;c)"}}, false},
+		{"escaped quote", `content:"\";"; a:b`, []Declaration{{"content", `"\";"`}, {"a", "b"}}, false},
+		{"trailing backslash", `a:b\`, []Declaration{{"a", `b\`}}, false},
+		{"no colon", "a b", nil, true},
+		{"no property", ":b", nil, true},
+		{"unterminated quote", `content:"a;b:c`, nil, true},
+		{"unterminated paren", `a:url(b;c:d`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitDeclarations(tt.css)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SplitDeclarations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitDeclarations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyle_SetStringUrl(t *testing.T) {
+	s := NewStyle()
+	if _, err := s.SetString("background: url(http://a.com/x.png) no-repeat; color: red"); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Get("background"); got != "url(http://a.com/x.png) no-repeat" {
+		t.Errorf("Get(background) = %q", got)
+	}
+}
+
+func ExampleStyle_SetResponsive() {
+	s := NewStyle()
+	_ = s.SetResponsive("font-size", 1, 2, "rem")
+	_ = s.SetResponsive("padding", 16, 32, "px")
+	fmt.Println(s.Get("font-size"))
+	fmt.Println(s.Get("padding"))
+	// Output: clamp(1rem, 0.6667rem + 1.6667vw, 2rem)
+	// clamp(16px, 10.6667px + 1.6667vw, 32px)
+}
+
+func TestStyle_SetResponsive(t *testing.T) {
+	s := NewStyle()
+	if err := s.SetResponsive("z-index", 1, 2, "px"); err == nil {
+		t.Error("expected an error on a non-length property")
+	}
+	if err := s.SetResponsive("width", 1, 2, "%"); err == nil {
+		t.Error("expected an error on an unsupported unit")
+	}
+	if err := s.SetResponsive("width", 2, 1, "px"); err == nil {
+		t.Error("expected an error when min > max")
+	}
+	_ = s.SetResponsive("width", 10, 10, "px")
+	if got := s.Get("width"); got != "10px" {
+		t.Errorf("equal min and max = %q", got)
+	}
+	_ = s.SetResponsive("--gap", 0, 96, "px")
+	if got := s.Get("--gap"); got != "clamp(0px, 10vw - 32px, 96px)" {
+		t.Errorf("negative intercept = %q", got)
+	}
+	_ = s.SetResponsive("margin", 2, 8, "px")
+	if got := s.Get("margin"); got != "clamp(2px, 0.625vw, 8px)" {
+		t.Errorf("zero intercept = %q", got)
+	}
+}
+
+func ExampleStyle_RemoveAnimations() {
+	s := Style{"color": "red", "transition": "all 1s", "animation-name": "spin", "animation-duration": "2s", "-webkit-transition-delay": "1s", "--animation": "x"}
+	removed := s.RemoveAnimations()
+	fmt.Println(removed)
+	fmt.Println(s)
+	// Output: [-webkit-transition-delay animation-duration animation-name transition]
+	// --animation:x;color:red
+}
+
+func ExampleStyle_SetTokenMath() {
+	s := Style{"margin": "4px 8px"}
+	_ = s.SetTokenMath("margin", 1, "*", 2)
+	fmt.Println(s)
+	// Output: margin:4px 16px
+}
+
+func TestStyle_SetTokenMath(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		index   int
+		op      string
+		val     float64
+		want    string
+		wantErr bool
+	}{
+		{"first", "4px 8px", 0, "+", 2, "6px 8px", false},
+		{"last", "1em 2em 3em 4em", 3, "/", 2, "1em 2em 3em 2em", false},
+		{"empty", "", 0, "+", 5, "5", false},
+		{"out of range", "4px 8px", 2, "+", 2, "4px 8px", true},
+		{"negative", "4px 8px", -1, "+", 2, "4px 8px", true},
+		{"bad op", "4px 8px", 0, "%", 2, "4px 8px", true},
+		{"non numeric", "auto 8px", 0, "+", 2, "auto 8px", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Style{}
+			if tt.value != "" {
+				s["margin"] = tt.value
+			}
+			err := s.SetTokenMath("margin", tt.index, tt.op, tt.val)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetTokenMath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := s.Get("margin"); got != tt.want {
+				t.Errorf("SetTokenMath() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyle_PropertyNormalization(t *testing.T) {
+	s := NewStyle()
+	s.Set("Color", "red")
+	s.Set(" color ", "blue")
+	s.Set("--Main", "a")
+	s.Set("--main", "b")
+	if s.Len() != 3 {
+		t.Errorf("expected 3 properties, got %v", s)
+	}
+	if s.Get("COLOR") != "blue" {
+		t.Errorf("expected case-insensitive lookup, got %q", s.Get("COLOR"))
+	}
+	if s.Get("--Main") != "a" || s.Get("--main") != "b" {
+		t.Errorf("expected custom properties to be case-sensitive, got %v", s)
+	}
+	if !s.Has("Color") {
+		t.Error("expected Has to be case-insensitive")
+	}
+	s.Remove("COLOR")
+	if s.Has("color") {
+		t.Error("expected Remove to be case-insensitive")
+	}
+
+	s2 := NewStyle()
+	_, _ = s2.SetString("Width: 4px; width: 5px; --X: a; --x: b")
+	if s2.String() != "--X:a;--x:b;width:5px" {
+		t.Errorf("SetString() got %s", s2)
+	}
+
+	s2.Merge(Style{"WIDTH": "6px"})
+	if s2.Get("width") != "6px" || s2.Len() != 3 {
+		t.Errorf("Merge() got %s", s2)
+	}
+}
+
+func TestStyle_LineHeightUnitless(t *testing.T) {
+	s := NewStyle()
+	s.Set("line-height", "1.5")
+	s.Set("height", "1.5")
+	if got := s.Get("line-height"); got != "1.5" {
+		t.Errorf("line-height got %q, want 1.5", got)
+	}
+	if got := s.Get("height"); got != "1.5px" {
+		t.Errorf("height got %q, want 1.5px", got)
+	}
+}
+
+func ExampleSplitLength() {
+	n, unit, ok := SplitLength("12.5rem")
+	fmt.Println(n, unit, ok)
+	// Output: 12.5 rem true
+}
+
+func TestSplitLength(t *testing.T) {
+	tests := []struct {
+		value  string
+		number float64
+		unit   string
+		ok     bool
+	}{
+		{"0", 0, "", true},
+		{"12px", 12, "px", true},
+		{" -4.5em ", -4.5, "em", true},
+		{".5vw", 0.5, "vw", true},
+		{"+3%", 3, "%", true},
+		{"1.5", 1.5, "", true},
+		{"4px 8px", 0, "", false},
+		{"auto", 0, "", false},
+		{"", 0, "", false},
+		{"px", 0, "", false},
+		{"5.px", 0, "", false},
+		{"calc(1px + 2px)", 0, "", false},
+	}
+	for _, tt := range tests {
+		n, unit, ok := SplitLength(tt.value)
+		if n != tt.number || unit != tt.unit || ok != tt.ok {
+			t.Errorf("SplitLength(%q) = %v, %q, %v, want %v, %q, %v", tt.value, n, unit, ok, tt.number, tt.unit, tt.ok)
+		}
+	}
+}
+
+func ExampleStyle_JSProperties() {
+	s := Style{"background-color": "red", "-webkit-transition": "all 1s", "float": "left"}
+	fmt.Println(s.CSSText())
+	fmt.Println(s.JSProperties())
+	// Output: -webkit-transition:all 1s;background-color:red;float:left
+	// map[WebkitTransition:all 1s backgroundColor:red cssFloat:left]
+}
+
+func ExampleStyle_CustomProperties() {
+	s := NewStyle()
+	_, _ = s.SetString("color: var(--Main-Color); --Main-Color: #333; --gap: calc(1rem + 2px)")
+	fmt.Println(s.CustomProperties())
+	fmt.Println(len(Style{"color": "red"}.CustomProperties()))
+	// Output: map[--Main-Color:#333 --gap:calc(1rem + 2px)]
+	// 0
+}
+
+func Test_jsPropertyName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"color", "color"},
+		{"border-top-left-radius", "borderTopLeftRadius"},
+		{"-moz-user-select", "MozUserSelect"},
+		{"-ms-transform", "msTransform"},
+		{"--main-color", "--main-color"},
+		{"float", "cssFloat"},
+	}
+	for _, tt := range tests {
+		if got := jsPropertyName(tt.in); got != tt.want {
+			t.Errorf("jsPropertyName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func ExampleStyle_MergeRespectingImportant() {
+	s := Style{"color": "red !important", "width": "4px"}
+	s.MergeRespectingImportant(Style{"color": "blue", "width": "8px"})
+	fmt.Println(s)
+	s.MergeRespectingImportant(Style{"color": "green !important"})
+	fmt.Println(s)
+	// Output: color:red !important;width:8px
+	// color:green !important;width:8px
+}
+
+func Test_isImportant(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"red", false},
+		{"red !important", true},
+		{"red ! IMPORTANT ", true},
+		{"red!important", true},
+		{"url(a!important.png)", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isImportant(tt.in); got != tt.want {
+			t.Errorf("isImportant(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func ExampleStyle_SetDefault() {
+	s := Style{"color": "red"}
+	fmt.Println(s.SetDefault("color", "blue"), s.SetDefault("width", "4"))
+	fmt.Println(s)
+	// Output: false true
+	// color:red;width:4px
+}
+
+func ExampleStyle_SetBorder() {
+	s := NewStyle()
+	_ = s.SetBorder("1", "solid", "#ccc")
+	_ = s.SetBorderSide("top", "thick", "Dashed", "")
+	fmt.Println(s)
+	// Output: border:1px solid #ccc;border-top:thick dashed
+}
+
+func TestStyle_SetBorder(t *testing.T) {
+	s := NewStyle()
+	if err := s.SetBorder("1", "solidd", "red"); err == nil {
+		t.Error("expected an error on an invalid style")
+	}
+	if err := s.SetBorder("", "", ""); err == nil {
+		t.Error("expected an error on an empty border")
+	}
+	if err := s.SetBorderSide("middle", "1", "solid", "red"); err == nil {
+		t.Error("expected an error on an invalid side")
+	}
+	if s.Len() != 0 {
+		t.Errorf("expected no changes on error, got %s", s)
+	}
+	if err := s.SetBorder("0", "", ""); err != nil || s.Get("border") != "0" {
+		t.Errorf("SetBorder() got %s, %v", s, err)
+	}
+	if err := s.SetBorder("1.5em", "none", ""); err != nil || s.Get("border") != "1.5em none" {
+		t.Errorf("SetBorder() got %s, %v", s, err)
+	}
+	if err := s.SetBorder("-", "solid", ""); err == nil {
+		t.Errorf("expected an error on a sign only width, got %s", s)
+	}
+	if err := s.SetBorder(".5", "", ""); err != nil || s.Get("border") != ".5px" {
+		t.Errorf("SetBorder() got %s, %v", s, err)
+	}
+	for _, w := range []string{"-1", "-.5", "-2px"} {
+		if err := s.SetBorder(w, "solid", ""); err == nil {
+			t.Errorf("expected an error on the negative width %s", w)
+		}
+	}
+}
+
+func ExampleStyle_SetTransforms() {
+	s := NewStyle().Set("transform", "translate(10px, 20px) rotate(45deg)")
+	s.SetTransforms(append(s.GetTransforms(), TransformFunc{"scale", []string{"1.5"}}))
+	fmt.Println(s)
+	// Output: transform:translate(10px, 20px) rotate(45deg) scale(1.5)
+}
+
+func TestStyle_GetTransforms(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []TransformFunc
+	}{
+		{"none", "none", nil},
+		{"empty", "", nil},
+		{"one", "rotate(45deg)", []TransformFunc{{"rotate", []string{"45deg"}}}},
+		{"spaces", " translate( 10px ,20px )  skewX(5deg) ", []TransformFunc{
+			{"translate", []string{"10px", "20px"}},
+			{"skewX", []string{"5deg"}},
+		}},
+		{"nested", "translate(calc(10px + 2%), var(--y, 1px)) scale(2)", []TransformFunc{
+			{"translate", []string{"calc(10px + 2%)", "var(--y, 1px)"}},
+			{"scale", []string{"2"}},
+		}},
+		{"matrix", "matrix(1,0,0,1,0,0)", []TransformFunc{{"matrix", []string{"1", "0", "0", "1", "0", "0"}}}},
+		{"no args", "f()", []TransformFunc{{"f", nil}}},
+		{"unclosed", "rotate(45deg", nil},
+		{"no function", "45deg", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Style{}
+			if tt.value != "" {
+				s["transform"] = tt.value
+			}
+			if got := s.GetTransforms(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTransforms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyle_SetTransforms(t *testing.T) {
+	s := NewStyle().Set("transform", "rotate(45deg) scale(2)")
+	funcs := s.GetTransforms()
+	funcs[1].Args[0] = "3"
+	s.SetTransforms(funcs)
+	if got := s.Get("transform"); got != "rotate(45deg) scale(3)" {
+		t.Errorf("SetTransforms() = %s", got)
+	}
+	s.SetTransforms(nil)
+	if s.Has("transform") {
+		t.Error("SetTransforms(nil) should remove the transform")
+	}
+}
+
+func ExampleStyle_GridColumns() {
+	s := Style{"grid-template-columns": "[full-start] minmax(1rem, 1fr) repeat(3, 1fr) [full-end]"}
+	fmt.Println(s.GridColumns())
+	// Output: [minmax(1rem, 1fr) 1fr 1fr 1fr]
+}
+
+func TestStyle_GridTracks(t *testing.T) {
+	tests := []struct {
+		name        string
+		style       Style
+		wantRows    []string
+		wantColumns []string
+	}{
+		{"none", Style{"grid-template-columns": "none"}, nil, nil},
+		{"empty", Style{}, nil, nil},
+		{"repeat", Style{"grid-template-rows": "repeat(2, 1fr 2fr) auto"}, []string{"1fr", "2fr", "1fr", "2fr", "auto"}, nil},
+		{"auto-fill", Style{"grid-template-columns": "repeat(auto-fill, minmax(100px, 1fr))"}, nil,
+			[]string{"repeat(auto-fill, minmax(100px, 1fr))"}},
+		{"fit-content", Style{"grid-template-columns": "fit-content(40%)  10px\t1fr"}, nil,
+			[]string{"fit-content(40%)", "10px", "1fr"}},
+		{"line names in repeat", Style{"grid-template-columns": "repeat(2, [col] 1fr)"}, nil, []string{"1fr", "1fr"}},
+		{"shorthand", Style{"grid-template": "100px 1fr / repeat(2, 50px)"}, []string{"100px", "1fr"}, []string{"50px", "50px"}},
+		{"shorthand areas", Style{"grid-template": `[header-top] "a a a" 40px [header-bottom] "b b b" 1fr / auto 1fr auto`},
+			[]string{"40px", "1fr"}, []string{"auto", "1fr", "auto"}},
+		{"longhand wins", Style{"grid-template": "1fr / 1fr", "grid-template-columns": "2fr 2fr"}, []string{"1fr"}, []string{"2fr", "2fr"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.GridRows(); !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("GridRows() = %v, want %v", got, tt.wantRows)
+			}
+			if got := tt.style.GridColumns(); !reflect.DeepEqual(got, tt.wantColumns) {
+				t.Errorf("GridColumns() = %v, want %v", got, tt.wantColumns)
+			}
+		})
+	}
+}
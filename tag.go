@@ -1,9 +1,12 @@
 package html5tag
 
 import (
+	"errors"
 	"fmt"
 	"html"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -29,6 +32,18 @@ const (
 	LabelWrapAfter
 )
 
+// WhitespacePolicy describes the white space written between a tag and its inner html.
+type WhitespacePolicy int
+
+const (
+	// WhitespaceNewline puts a newline between the tag and its inner html on both sides. This is what RenderTag does.
+	WhitespaceNewline WhitespacePolicy = iota
+	// WhitespaceNone puts nothing between the tag and its inner html. This is what RenderTagNoSpace does.
+	WhitespaceNone
+	// WhitespaceSpace puts a single space between the tag and its inner html on both sides.
+	WhitespaceSpace
+)
+
 // VoidTag represents a void tag, which is a tag that does not need a matching closing tag.
 type VoidTag struct {
 	Tag  string
@@ -42,17 +57,95 @@ func (t VoidTag) Render() string {
 
 // RenderVoidTag renders a void tag using the given tag name and attributes.
 func RenderVoidTag(tag string, attr Attributes) (s string) {
+	s, _ = RenderVoidTagN(tag, attr)
+	return
+}
+
+// RenderVoidTagN is like RenderVoidTag, but also returns the number of bytes written.
+func RenderVoidTagN(tag string, attr Attributes) (s string, n int) {
 	b := strings.Builder{}
-	_, err := WriteVoidTag(&b, tag, attr)
+	n, err := WriteVoidTag(&b, tag, attr)
 	if err != nil {
 		panic(err)
 	}
-	return b.String()
+	return b.String(), n
 }
 
 // WriteVoidTag writes a void tag to the io.Writer.
 func WriteVoidTag(w io.Writer, tag string, attr Attributes) (n int, err error) {
-	return writeTag(w, tag, attr, nil, true, false, false)
+	return writeTag(nil, w, tag, attr, nil, true, WhitespaceNewline, false)
+}
+
+// OpenTag returns just the opening tag of the given tag, with its attributes. Use it with CloseTag when the
+// content of the tag is written separately.
+func OpenTag(tag string, attr Attributes) string {
+	b := strings.Builder{}
+	_, err := writeTag(nil, &b, tag, attr, nil, true, WhitespaceNewline, false)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// CloseTag returns the closing tag of the given tag, or an empty string if the tag is a void tag, like "img",
+// which has no closing tag.
+func CloseTag(tag string) string {
+	if voidTags[tag] {
+		return ""
+	}
+	return "</" + tag + ">"
+}
+
+// CanonicalizeTag rewrites the opening tag at the start of tagHtml in a canonical form, so that tags that mean
+// the same thing compare as equal strings. Anything after the opening tag, like inner html and a closing tag,
+// is returned unchanged.
+//
+// The tag and attribute names are lowercased, the attributes are written in the same order as WriteTo, classes
+// are sorted with duplicates removed, style properties are sorted, and values are written with double quotes and
+// escaped. The "/" of a self-closing void tag, like <br/>, is removed. An error is returned if tagHtml does not
+// start with an opening tag, or if its attributes cannot be parsed.
+func CanonicalizeTag(tagHtml string) (string, error) {
+	s := strings.TrimSpace(tagHtml)
+	if !strings.HasPrefix(s, "<") {
+		return "", errors.New("html does not start with a tag")
+	}
+	tag := scanTagName(s[1:])
+	if tag == "" {
+		return "", errors.New("html does not start with an opening tag")
+	}
+	end, selfClosing := scanTagEnd(s)
+	if s[end-1] != '>' {
+		return "", fmt.Errorf("<%s> is not closed", tag)
+	}
+	a, err := ParseAttributes(strings.TrimSuffix(s[1+len(tag):end-1], "/"))
+	if err != nil {
+		return "", err
+	}
+	a.Normalize()
+	if c, ok := a["class"]; ok {
+		classes := strings.Fields(c)
+		sort.Strings(classes)
+		a["class"] = MergeWords("", strings.Join(classes, " "))
+	}
+	if st, ok := a["style"]; ok {
+		styles := NewStyle()
+		if _, err = styles.SetString(st); err != nil {
+			return "", err
+		}
+		a["style"] = styles.String()
+	}
+
+	b := strings.Builder{}
+	b.WriteString("<" + tag)
+	if a.isRendered() {
+		b.WriteString(" " + a.String())
+	}
+	if selfClosing && !voidTags[tag] {
+		b.WriteString("/")
+	}
+	b.WriteString(">")
+	b.WriteString(s[end:])
+	return b.String(), nil
 }
 
 // RenderTag renders a standard html tag with a closing tag.
@@ -66,17 +159,45 @@ func WriteVoidTag(w io.Writer, tag string, attr Attributes) (n int, err error) {
 // In the few situations where you would want to
 // get rid of this space, call RenderTagNoSpace()
 func RenderTag(tag string, attr Attributes, innerHtml string) string {
+	s, _ := RenderTagN(tag, attr, innerHtml)
+	return s
+}
+
+// RenderTagN is like RenderTag, but also returns the number of bytes written, as reported by WriteTag.
+func RenderTagN(tag string, attr Attributes, innerHtml string) (string, int) {
 	b := strings.Builder{}
 	var wto io.WriterTo
 	if innerHtml != "" {
 		wto = strings.NewReader(innerHtml)
 	}
 
-	_, err := WriteTag(&b, tag, attr, wto)
+	n, err := WriteTag(&b, tag, attr, wto)
 	if err != nil {
 		panic(err)
 	}
-	return b.String()
+	return b.String(), n
+}
+
+// RenderTagCollectClasses is like RenderTag, but also returns the set of class names used by the tag and by all
+// the tags in innerHtml. Use it to find the css classes that a page actually uses.
+func RenderTagCollectClasses(tag string, attr Attributes, innerHtml string) (html string, classes map[string]struct{}) {
+	html = RenderTag(tag, attr, innerHtml)
+	classes = make(map[string]struct{})
+	scanTags(html, func(t scannedTag) {
+		if t.isEnd || indexASCIIFold(t.attributes, "class") < 0 {
+			return
+		}
+		// only the class is read, so that another attribute that is not valid does not lose the classes
+		_ = scanAttributes(t.attributes, func(name, val string) error {
+			if strings.EqualFold(name, "class") {
+				for _, c := range strings.Fields(val) {
+					classes[c] = struct{}{}
+				}
+			}
+			return nil
+		})
+	})
+	return
 }
 
 // RenderTagFormatted renders the tag, pretty prints the innerHtml and sorts the attributes.
@@ -97,32 +218,38 @@ func RenderTagFormatted(tag string, attr Attributes, innerHtml string) string {
 
 // WriteTag writes the tag to the io.Writer.
 func WriteTag(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo) (n int, err error) {
-	return writeTag(w, tag, attr, innerHtml, false, false, false)
+	return writeTag(nil, w, tag, attr, innerHtml, false, WhitespaceNewline, false)
 }
 
 // WriteTagFormatted writes the tag to the io.Writer, pretty prints the innerHtml and sorts the attributes.
 func WriteTagFormatted(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo) (n int, err error) {
-	return writeTag(w, tag, attr, innerHtml, false, false, true)
+	return writeTag(nil, w, tag, attr, innerHtml, false, WhitespaceNewline, true)
 }
 
 // RenderTagNoSpace is similar to RenderTag, but should be used in situations where the tag is an
 // inline tag that you want to visually be right next to its neighbors with no space.
 func RenderTagNoSpace(tag string, attr Attributes, innerHtml string) string {
+	s, _ := RenderTagNoSpaceN(tag, attr, innerHtml)
+	return s
+}
+
+// RenderTagNoSpaceN is like RenderTagNoSpace, but also returns the number of bytes written.
+func RenderTagNoSpaceN(tag string, attr Attributes, innerHtml string) (string, int) {
 	b := strings.Builder{}
 	var wto io.WriterTo
 	if innerHtml != "" {
 		wto = strings.NewReader(innerHtml)
 	}
-	_, err := WriteTagNoSpace(&b, tag, attr, wto)
+	n, err := WriteTagNoSpace(&b, tag, attr, wto)
 	if err != nil {
 		panic(err)
 	}
-	return b.String()
+	return b.String(), n
 }
 
 // WriteTagNoSpace writes the tag to the io.Writer, and does not add any spaces between the tag and the innerHtml.
 func WriteTagNoSpace(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo) (n int, err error) {
-	return writeTag(w, tag, attr, innerHtml, false, true, false)
+	return writeTag(nil, w, tag, attr, innerHtml, false, WhitespaceNone, false)
 }
 
 // RenderTagNoSpaceFormatted will render without formatting the innerHtml, but WILL sort the attributes.
@@ -142,7 +269,95 @@ func RenderTagNoSpaceFormatted(tag string, attr Attributes, innerHtml string) st
 
 // WriteTagNoSpaceFormatted writes to tag without formatting the innerHtml, but WILL sort the attributes.
 func WriteTagNoSpaceFormatted(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo) (n int, err error) {
-	return writeTag(w, tag, attr, innerHtml, false, true, true)
+	return writeTag(nil, w, tag, attr, innerHtml, false, WhitespaceNone, true)
+}
+
+// RenderTagWhitespace is like RenderTag, but uses the given policy to decide what white space to put
+// between the tag and its inner html.
+func RenderTagWhitespace(tag string, attr Attributes, innerHtml string, ws WhitespacePolicy) string {
+	b := strings.Builder{}
+	var wto io.WriterTo
+	if innerHtml != "" {
+		wto = strings.NewReader(innerHtml)
+	}
+	_, err := WriteTagWhitespace(&b, tag, attr, wto, ws)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteTagWhitespace writes the tag to the io.Writer, using the given policy to decide what white space to put
+// between the tag and its inner html.
+func WriteTagWhitespace(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo, ws WhitespacePolicy) (n int, err error) {
+	return writeTag(nil, w, tag, attr, innerHtml, false, ws, false)
+}
+
+// RenderTagIfContent is like RenderTag, but returns an empty string if innerHtml is empty or only white space,
+// so that wrappers around conditional content are left out rather than rendered empty.
+func RenderTagIfContent(tag string, attr Attributes, innerHtml string) string {
+	b := strings.Builder{}
+	_, err := WriteTagIfContent(&b, tag, attr, strings.NewReader(innerHtml))
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteTagIfContent is like WriteTag, but writes nothing if innerHtml writes nothing, or only white space.
+//
+// The tag is held back until the first byte of innerHtml that is not white space arrives, at which point
+// the held back html is written and the rest of the tag is streamed to w.
+func WriteTagIfContent(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo) (n int, err error) {
+	if innerHtml == nil {
+		return
+	}
+	g := &contentGate{w: w}
+	_, err = writeTag(nil, g, tag, attr, contentGateWriterTo{g, innerHtml}, false, WhitespaceNewline, false)
+	return g.n, err
+}
+
+// contentGate is a writer that holds back what is written to it until the inner html of a tag writes something
+// that is not white space. If that never happens, nothing is written to w.
+type contentGate struct {
+	w         io.Writer
+	pending   []byte
+	inContent bool
+	started   bool
+	n         int
+}
+
+func (g *contentGate) Write(p []byte) (int, error) {
+	if !g.started {
+		if !g.inContent || strings.TrimLeft(string(p), " \t\n\r\f") == "" {
+			g.pending = append(g.pending, p...)
+			return len(p), nil
+		}
+		g.started = true
+		n, err := g.w.Write(g.pending)
+		g.n += n
+		if err != nil {
+			return 0, err
+		}
+		g.pending = nil
+	}
+	n, err := g.w.Write(p)
+	g.n += n
+	return n, err
+}
+
+// contentGateWriterTo writes inner html, telling the contentGate that what is written is the content of the tag.
+type contentGateWriterTo struct {
+	g     *contentGate
+	inner io.WriterTo
+}
+
+// WriteTo implements the io.WriterTo interface.
+func (c contentGateWriterTo) WriteTo(w io.Writer) (n int64, err error) {
+	c.g.inContent = true
+	n, err = c.inner.WriteTo(w)
+	c.g.inContent = false
+	return
 }
 
 // writeString is a version of io.WriteString that accumulates the total written from previous writes.
@@ -152,17 +367,19 @@ func writeString(w io.Writer, s string, n int) (n2 int, err error) {
 	return
 }
 
-// writeTag is the main formatter of tags.
-func writeTag(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo, isVoid bool, noSpace bool, format bool) (n int, err error) {
+// writeTag is the main formatter of tags. The options of r are applied to the attributes. r may be nil.
+func writeTag(r *Renderer, w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo, isVoid bool, ws WhitespacePolicy, format bool) (n int, err error) {
 	var n3 int64
 
+	attr = r.apply(tag, attr)
+
 	if n, err = writeString(w, "<", n); err != nil {
 		return
 	}
 	if n, err = writeString(w, tag, n); err != nil {
 		return
 	}
-	if len(attr) != 0 {
+	if attr.isRendered() {
 		if n, err = writeString(w, " ", n); err != nil {
 			return
 		}
@@ -189,6 +406,18 @@ func writeTag(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo, i
 		return
 	}
 
+	if tag == "template" {
+		// The content of a template is inert, and must be passed through exactly as given
+		ws = WhitespaceNone
+	}
+	var space string
+	switch ws {
+	case WhitespaceNewline:
+		space = "\n"
+	case WhitespaceSpace:
+		space = " "
+	}
+
 	if innerHtml != nil {
 		builder := strings.Builder{}
 		innerW := w
@@ -197,9 +426,9 @@ func writeTag(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo, i
 		if format {
 			innerW = &builder
 		}
-		if !noSpace {
+		if space != "" {
 			// required for consistency, will force a space between itself and its neighbors in certain situations
-			if innerN, err = writeString(innerW, "\n", innerN); err != nil {
+			if innerN, err = writeString(innerW, space, innerN); err != nil {
 				return
 			}
 		}
@@ -211,8 +440,8 @@ func writeTag(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo, i
 			}
 			return
 		}
-		if !noSpace {
-			if innerN, err = writeString(innerW, "\n", innerN); err != nil {
+		if space != "" {
+			if innerN, err = writeString(innerW, space, innerN); err != nil {
 				if !format {
 					n += innerN
 				}
@@ -221,7 +450,7 @@ func writeTag(w io.Writer, tag string, attr Attributes, innerHtml io.WriterTo, i
 		}
 		if format {
 			s := builder.String()
-			if !noSpace {
+			if ws == WhitespaceNewline {
 				s = Indent(s)
 			}
 			if n, err = writeString(w, s, n); err != nil {
@@ -276,15 +505,64 @@ func makeWritersTo(items ...io.WriterTo) io.WriterTo {
 	return b
 }
 
+// tagWriterTo is an io.WriterTo that writes a tag wrapped around inner html, with no space between them.
+type tagWriterTo struct {
+	r     *Renderer
+	tag   string
+	attr  Attributes
+	inner io.WriterTo
+}
+
+// WriteTo implements the io.WriterTo interface.
+func (t tagWriterTo) WriteTo(w io.Writer) (n int64, err error) {
+	n2, err := writeTag(t.r, w, t.tag, t.attr, t.inner, false, WhitespaceNone, false)
+	return int64(n2), err
+}
+
 // WriteLabel is a utility function to render a label, together with its text.
 // Various CSS frameworks require labels to be rendered a certain way.
 func WriteLabel(w io.Writer, labelAttributes Attributes, label string, ctrlHtml io.WriterTo, mode LabelDrawingMode) (n int, err error) {
+	return WriteLabelWrapped(w, labelAttributes, label, ctrlHtml, mode, "", nil)
+}
+
+// RenderLabelWrapped is like RenderLabel, but wraps the control in a tag with the given name and attributes.
+// Some CSS frameworks require this. If wrapperTag is empty, the control will not be wrapped.
+func RenderLabelWrapped(labelAttributes Attributes, label string, ctrlHtml string, mode LabelDrawingMode, wrapperTag string, wrapperAttributes Attributes) string {
+	b := strings.Builder{}
+
+	var wto io.WriterTo
+	if ctrlHtml != "" {
+		wto = strings.NewReader(ctrlHtml)
+	}
+	_, err := WriteLabelWrapped(&b, labelAttributes, label, wto, mode, wrapperTag, wrapperAttributes)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteLabelWrapped is like WriteLabel, but wraps the control in a tag with the given name and attributes.
+// For example, passing a wrapperTag of "div" with a LabelBefore mode will produce:
+//
+//	<label>MyLabel</label> <div><input ... /></div>
+//
+// If wrapperTag is empty, the control will not be wrapped.
+func WriteLabelWrapped(w io.Writer, labelAttributes Attributes, label string, ctrlHtml io.WriterTo, mode LabelDrawingMode, wrapperTag string, wrapperAttributes Attributes) (n int, err error) {
+	return writeLabel(nil, w, labelAttributes, html.EscapeString(label), ctrlHtml, mode, wrapperTag, wrapperAttributes)
+}
+
+// writeLabel writes a label with the given label html around or next to the control, applying the options of r.
+func writeLabel(r *Renderer, w io.Writer, labelAttributes Attributes, label string, ctrlHtml io.WriterTo, mode LabelDrawingMode, wrapperTag string, wrapperAttributes Attributes) (n int, err error) {
+	if wrapperTag != "" {
+		ctrlHtml = tagWriterTo{r, wrapperTag, wrapperAttributes, ctrlHtml}
+	} else if ctrlHtml == nil {
+		ctrlHtml = strings.NewReader("")
+	}
 	var n64 int64
 	var n2 int
-	label = html.EscapeString(label)
 	switch mode {
 	case LabelBefore:
-		if n, err = WriteTagNoSpace(w, "label", labelAttributes, strings.NewReader(label)); err != nil {
+		if n, err = writeTag(r, w, "label", labelAttributes, strings.NewReader(label), false, WhitespaceNone, false); err != nil {
 			return
 		}
 		if n, err = writeString(w, " ", n); err != nil {
@@ -302,17 +580,93 @@ func WriteLabel(w io.Writer, labelAttributes Attributes, label string, ctrlHtml
 		if n, err = writeString(w, " ", n); err != nil {
 			return
 		}
-		n2, err = WriteTagNoSpace(w, "label", labelAttributes, strings.NewReader(label))
+		n2, err = writeTag(r, w, "label", labelAttributes, strings.NewReader(label), false, WhitespaceNone, false)
 		n += n2
 		return
 	case LabelWrapBefore:
-		return WriteTag(w, "label", labelAttributes, makeWritersTo(strings.NewReader(label+" "), ctrlHtml))
+		return writeTag(r, w, "label", labelAttributes, makeWritersTo(strings.NewReader(label+" "), ctrlHtml), false, WhitespaceNewline, false)
 	case LabelWrapAfter:
-		return WriteTag(w, "label", labelAttributes, makeWritersTo(ctrlHtml, strings.NewReader(" "+label)))
+		return writeTag(r, w, "label", labelAttributes, makeWritersTo(ctrlHtml, strings.NewReader(" "+label)), false, WhitespaceNewline, false)
 	}
 	panic("Unknown label mode")
 }
 
+// DefaultRequiredIndicator is the html that RenderLabelRequired adds to the end of the label of a required field.
+// It is hidden from screen readers, since they announce the aria-required attribute of the control instead.
+// Use a Renderer with a RequiredIndicator to match the css framework in use.
+const DefaultRequiredIndicator = ` <span class="required" aria-hidden="true">*</span>`
+
+// RenderLabelRequired is like RenderLabel, but if required is true, it adds the DefaultRequiredIndicator to the label,
+// and adds aria-required="true" to the first tag in ctrlHtml, unless that tag already has a required or
+// aria-required attribute.
+func RenderLabelRequired(labelAttributes Attributes, label string, ctrlHtml string, mode LabelDrawingMode, required bool) string {
+	b := strings.Builder{}
+
+	var wto io.WriterTo
+	if ctrlHtml != "" {
+		wto = strings.NewReader(ctrlHtml)
+	}
+	_, err := WriteLabelRequired(&b, labelAttributes, label, wto, mode, required)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteLabelRequired is like WriteLabel, but if required is true, it adds the DefaultRequiredIndicator to the label,
+// and adds aria-required="true" to the first tag in ctrlHtml. See RenderLabelRequired.
+//
+// When required is true, ctrlHtml is buffered so that the attribute can be added.
+func WriteLabelRequired(w io.Writer, labelAttributes Attributes, label string, ctrlHtml io.WriterTo, mode LabelDrawingMode, required bool) (n int, err error) {
+	return writeLabelRequired(nil, w, labelAttributes, label, ctrlHtml, mode, required)
+}
+
+// writeLabelRequired writes a label for a control that may be required, applying the options of r.
+func writeLabelRequired(r *Renderer, w io.Writer, labelAttributes Attributes, label string, ctrlHtml io.WriterTo, mode LabelDrawingMode, required bool) (n int, err error) {
+	if !required {
+		return writeLabel(r, w, labelAttributes, html.EscapeString(label), ctrlHtml, mode, "", nil)
+	}
+	if ctrlHtml != nil {
+		b := strings.Builder{}
+		if _, err = ctrlHtml.WriteTo(&b); err != nil {
+			return
+		}
+		ctrlHtml = strings.NewReader(addRequiredAttribute(b.String()))
+	}
+	return writeLabel(r, w, labelAttributes, html.EscapeString(label)+r.requiredIndicator(), ctrlHtml, mode, "", nil)
+}
+
+// addRequiredAttribute adds aria-required="true" to the first opening tag in ctrlHtml, if it does not
+// already have a required or aria-required attribute.
+func addRequiredAttribute(ctrlHtml string) string {
+	found := false
+	scanTags(ctrlHtml, func(t scannedTag) {
+		if found || t.isEnd {
+			return
+		}
+		found = true
+		if a, err := ParseAttributes(t.attributes); err != nil || a.Has("required") || a.Has("aria-required") {
+			return
+		}
+		end, selfClosing := scanTagEnd(ctrlHtml[t.offset:])
+		end += t.offset - 1 // the offset of the closing >
+		if selfClosing {
+			end--
+		}
+		for end > t.offset && strings.IndexByte(" \t\n\r\f", ctrlHtml[end-1]) != -1 {
+			end--
+		}
+		ctrlHtml = ctrlHtml[:end] + ` aria-required="true"` + ctrlHtml[end:]
+	})
+	return ctrlHtml
+}
+
+// RenderTemplate renders a template tag. innerHtml is written exactly as given, without added spaces or
+// formatting, since the content of a template is inert and may depend on its whitespace.
+func RenderTemplate(attr Attributes, innerHtml string) string {
+	return RenderTag("template", attr, innerHtml)
+}
+
 // RenderImage renders an image tag with the given source, alt and attribute values.
 // Panics on error.
 func RenderImage(src string, alt string, attributes Attributes) string {
@@ -330,27 +684,328 @@ func WriteImage(w io.Writer, src string, alt string, attributes Attributes) (n i
 	return WriteVoidTag(w, "img", a)
 }
 
+// SrcSetEntry is one image in the srcset attribute of an image. Set either Width, the width of the image in pixels,
+// or Density, the pixel density the image is for, like 2 for a 2x image, but not both.
+type SrcSetEntry struct {
+	URL     string
+	Width   int
+	Density float64
+}
+
+// BuildSrcSet returns the value of a srcset attribute for the given images. All the entries must use the same
+// kind of descriptor, either Width or Density. An entry with neither is the same as a density of 1, so it
+// cannot be mixed with entries that use Width.
+func BuildSrcSet(entries []SrcSetEntry) (string, error) {
+	var items []string
+	var hasWidth, hasDensity bool
+	for _, e := range entries {
+		if e.URL == "" || strings.ContainsAny(e.URL, " \t\n\r\f") || strings.HasPrefix(e.URL, ",") || strings.HasSuffix(e.URL, ",") {
+			return "", fmt.Errorf("%q is not a valid srcset url", e.URL)
+		}
+		switch {
+		case e.Width != 0 && e.Density != 0:
+			return "", fmt.Errorf("srcset entry %q cannot have both a width and a density", e.URL)
+		case e.Width < 0 || e.Density < 0:
+			return "", fmt.Errorf("srcset entry %q has a negative descriptor", e.URL)
+		case e.Width != 0:
+			hasWidth = true
+			items = append(items, e.URL+" "+strconv.Itoa(e.Width)+"w")
+		case e.Density != 0:
+			hasDensity = true
+			items = append(items, e.URL+" "+strconv.FormatFloat(e.Density, 'f', -1, 64)+"x")
+		default:
+			hasDensity = true
+			items = append(items, e.URL)
+		}
+	}
+	if hasWidth && hasDensity {
+		return "", errors.New("a srcset cannot mix width and density descriptors")
+	}
+	return strings.Join(items, ", "), nil
+}
+
+// RenderResponsiveImage renders an image tag with a srcset attribute built from srcset, and a sizes attribute.
+// src is the image used by browsers that do not support srcset. sizes is required if the entries use widths,
+// since the browser cannot choose an image without it.
+// Panics on error.
+func RenderResponsiveImage(src, alt string, srcset []SrcSetEntry, sizes string, attr Attributes) string {
+	b := strings.Builder{}
+	_, err := WriteResponsiveImage(&b, src, alt, srcset, sizes, attr)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteResponsiveImage writes an image tag with a srcset attribute built from srcset, and a sizes attribute.
+//
+// See RenderResponsiveImage.
+func WriteResponsiveImage(w io.Writer, src, alt string, srcset []SrcSetEntry, sizes string, attr Attributes) (n int, err error) {
+	set, err := BuildSrcSet(srcset)
+	if err != nil {
+		return
+	}
+	a := attr.Copy()
+	if set != "" {
+		a.Set("srcset", set)
+	}
+	sizes = strings.TrimSpace(sizes)
+	if sizes != "" {
+		a.Set("sizes", sizes)
+	} else {
+		for _, e := range srcset {
+			if e.Width != 0 {
+				err = errors.New("sizes is required when srcset uses widths")
+				return
+			}
+		}
+	}
+	return WriteImage(w, src, alt, a)
+}
+
+// RenderDetails renders a details tag, with a summary tag inside it, followed by contentHtml.
+// The summary is text and will be escaped. If open is true, the details will be rendered in the open state.
+// Panics on error.
+func RenderDetails(summaryAttr Attributes, summary string, open bool, contentHtml string, detailsAttr Attributes) string {
+	b := strings.Builder{}
+	var wto io.WriterTo
+	if contentHtml != "" {
+		wto = strings.NewReader(contentHtml)
+	}
+	_, err := WriteDetails(&b, summaryAttr, summary, open, wto, detailsAttr)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteDetails writes a details tag, with a summary tag inside it, followed by contentHtml.
+// The summary is text and will be escaped. If open is true, the details will be written in the open state.
+func WriteDetails(w io.Writer, summaryAttr Attributes, summary string, open bool, contentHtml io.WriterTo, detailsAttr Attributes) (n int, err error) {
+	a := detailsAttr.Copy()
+	if open {
+		a.Set("open", "")
+	} else {
+		a.RemoveAttribute("open")
+	}
+	s := tagWriterTo{nil, "summary", summaryAttr, strings.NewReader(html.EscapeString(summary))}
+	if contentHtml == nil {
+		return WriteTag(w, "details", a, s)
+	}
+	return WriteTag(w, "details", a, makeWritersTo(s, strings.NewReader("\n"), contentHtml))
+}
+
+// RenderForm renders a form tag with the given action and method, which must be "get" or "post" in any case.
+// If csrfToken is not empty, a hidden input named csrfTokenName with the token as its value is
+// rendered at the start of the form, followed by innerHtml.
+// Panics on error.
+func RenderForm(action, method string, attr Attributes, csrfTokenName, csrfToken string, innerHtml string) string {
+	b := strings.Builder{}
+	var wto io.WriterTo
+	if innerHtml != "" {
+		wto = strings.NewReader(innerHtml)
+	}
+	_, err := WriteForm(&b, action, method, attr, csrfTokenName, csrfToken, wto)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteForm writes a form tag with the given action and method, which must be "get" or "post" in any case.
+// If csrfToken is not empty, a hidden input named csrfTokenName with the token as its value is
+// written at the start of the form, followed by innerHtml.
+func WriteForm(w io.Writer, action, method string, attr Attributes, csrfTokenName, csrfToken string, innerHtml io.WriterTo) (n int, err error) {
+	method = strings.ToLower(method)
+	if method != "get" && method != "post" {
+		err = fmt.Errorf("invalid form method %q", method)
+		return
+	}
+	if csrfToken != "" && csrfTokenName == "" {
+		err = errors.New("a csrf token requires a name")
+		return
+	}
+	a := attr.Copy().Set("action", action).Set("method", method)
+	var inner writerItems
+	if csrfToken != "" {
+		input := Attributes{"type": "hidden", "name": csrfTokenName, "value": csrfToken}
+		inner = append(inner, strings.NewReader(RenderVoidTag("input", input)))
+		if innerHtml != nil {
+			inner = append(inner, strings.NewReader("\n"))
+		}
+	}
+	if innerHtml != nil {
+		inner = append(inner, innerHtml)
+	}
+	if inner == nil {
+		return WriteTag(w, "form", a, nil)
+	}
+	return WriteTag(w, "form", a, inner)
+}
+
+// RenderButton renders a button tag with the given type and label. buttonType must be "button", "submit" or "reset",
+// since a button without a type is a submit button, which can cause a form to be submitted by accident.
+// The label is text and will be escaped. The arguments are in the same order as RenderTag, with the type in place
+// of the tag. Panics on error.
+func RenderButton(buttonType string, attr Attributes, label string) string {
+	return RenderButtonHTML(buttonType, attr, html.EscapeString(label))
+}
+
+// RenderButtonHTML is like RenderButton, but labelHtml is html, and must already be escaped if needed.
+// Panics on error.
+func RenderButtonHTML(buttonType string, attr Attributes, labelHtml string) string {
+	b := strings.Builder{}
+	var wto io.WriterTo
+	if labelHtml != "" {
+		wto = strings.NewReader(labelHtml)
+	}
+	_, err := WriteButton(&b, buttonType, attr, wto)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteButton writes a button tag with the given type, which must be "button", "submit" or "reset".
+// labelHtml is html, and must already be escaped if needed.
+func WriteButton(w io.Writer, buttonType string, attr Attributes, labelHtml io.WriterTo) (n int, err error) {
+	switch buttonType {
+	case "button", "submit", "reset":
+	default:
+		err = fmt.Errorf("invalid button type %q", buttonType)
+		return
+	}
+	a := attr.Copy().Set("type", buttonType)
+	return WriteTagNoSpace(w, "button", a, labelHtml)
+}
+
+// ListItem is an item in a list rendered by RenderList.
+type ListItem struct {
+	// Text is the content of the item. It will be escaped. It is used only if Html is empty.
+	Text string
+	// Html is the content of the item, and must already be escaped if needed.
+	Html string
+	// Attributes are the attributes of the li tag.
+	Attributes Attributes
+}
+
+// RenderList renders a list of items as an ol tag if ordered is true, or a ul tag if not, with each item
+// in a li tag. Panics on error.
+func RenderList(ordered bool, listAttr Attributes, items []ListItem) string {
+	b := strings.Builder{}
+	_, err := WriteList(&b, ordered, listAttr, items)
+	if err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// WriteList writes a list of items as an ol tag if ordered is true, or a ul tag if not, with each item
+// in a li tag.
+func WriteList(w io.Writer, ordered bool, listAttr Attributes, items []ListItem) (n int, err error) {
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	var inner writerItems
+	for i, item := range items {
+		if i > 0 {
+			inner = append(inner, strings.NewReader("\n"))
+		}
+		content := item.Html
+		if content == "" {
+			content = html.EscapeString(item.Text)
+		}
+		inner = append(inner, tagWriterTo{nil, "li", item.Attributes, strings.NewReader(content)})
+	}
+	if inner == nil {
+		return WriteTag(w, tag, listAttr, nil)
+	}
+	return WriteTag(w, tag, listAttr, inner)
+}
+
+// verbatimTags are tags whose content must not be changed by formatting.
+var verbatimTags = []string{"textarea", "template"}
+
 // Indent will add space to the front of every line in the string. Since indent is used to format code for reading
 // while we are in development mode, we do not need it to be particularly efficient.
-// It will not do this for textarea tags, since that would change the text in the tag.
+// It will not do this for textarea and template tags, since that would change the content of the tag.
 func Indent(s string) string {
 	var out string
-	var taOffset int
 	for {
-		taOffset = strings.Index(s, "<textarea")
-		if taOffset == -1 {
+		tag, offset := nextVerbatimTag(s)
+		if offset == -1 {
 			out += indent(s)
 			return out
 		}
-		out += indent(s[:taOffset])
-		s = s[taOffset:]
-		taOffset = strings.Index(s, "</textarea>")
-		if taOffset == -1 {
+		out += indent(s[:offset])
+		s = s[offset:]
+		end := verbatimTagEnd(s, tag)
+		if end == -1 {
 			// This is an error in the html, so just return the original
 			return s
 		}
-		out += s[:taOffset+11] // skip textarea close tag
-		s = s[taOffset+11:]
+		out += s[:end]
+		s = s[end:]
+	}
+}
+
+// nextVerbatimTag returns the name and offset of the first tag in s whose content should not be indented,
+// or an offset of -1 if there is none.
+func nextVerbatimTag(s string) (tag string, offset int) {
+	offset = -1
+	for _, t := range verbatimTags {
+		if o := indexOpenTag(s, t); o != -1 && (offset == -1 || o < offset) {
+			tag = t
+			offset = o
+		}
+	}
+	return
+}
+
+// indexOpenTag returns the offset of the first opening tag with the given name in s, or -1 if not found.
+func indexOpenTag(s string, tag string) int {
+	var offset int
+	for {
+		i := strings.Index(s[offset:], "<"+tag)
+		if i == -1 {
+			return -1
+		}
+		i += offset
+		after := i + len(tag) + 1
+		if after == len(s) || strings.IndexByte(" \t\n\r\f/>", s[after]) != -1 {
+			return i
+		}
+		offset = after
+	}
+}
+
+// verbatimTagEnd returns the offset just past the end tag that matches the opening tag at the start of s,
+// taking into account nested tags of the same name. Returns -1 if there is no matching end tag.
+func verbatimTagEnd(s string, tag string) int {
+	closeTag := "</" + tag + ">"
+	depth := 0
+	offset := 0
+	for {
+		c := strings.Index(s[offset:], closeTag)
+		if c == -1 {
+			return -1
+		}
+		c += offset
+		// count the nested opening tags before this close tag
+		for o := offset; ; {
+			i := indexOpenTag(s[o:c], tag)
+			if i == -1 {
+				break
+			}
+			depth++
+			o += i + len(tag) + 1
+		}
+		depth--
+		offset = c + len(closeTag)
+		if depth <= 0 {
+			return offset
+		}
 	}
 }
 
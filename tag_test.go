@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -37,6 +38,103 @@ func ExampleRenderTagNoSpace() {
 	// Output: <div id="me">Here I am</div>
 }
 
+func ExampleRenderTagIfContent() {
+	fmt.Printf("%q\n", RenderTagIfContent("div", Attributes{"id": "me"}, " \n "))
+	fmt.Println(RenderTagIfContent("div", Attributes{"id": "me"}, "Here I am"))
+	// Output: ""
+	// <div id="me">
+	// Here I am
+	// </div>
+}
+
+// chunkedWriterTo writes each of its chunks with a separate call to Write.
+type chunkedWriterTo []string
+
+func (c chunkedWriterTo) WriteTo(w io.Writer) (n int64, err error) {
+	for _, s := range c {
+		var n1 int
+		n1, err = io.WriteString(w, s)
+		n += int64(n1)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func TestWriteTagIfContent(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		chunks chunkedWriterTo
+		want   string
+	}{
+		{"empty", "div", nil, ""},
+		{"white space", "div", chunkedWriterTo{" ", "\n\t", ""}, ""},
+		{"content", "div", chunkedWriterTo{"a", "b"}, "<div>\nab\n</div>"},
+		{"content after white space", "div", chunkedWriterTo{"  ", " <b>x</b>"}, "<div>\n   <b>x</b>\n</div>"},
+		{"template", "template", chunkedWriterTo{"a"}, "<template>a</template>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := strings.Builder{}
+			n, err := WriteTagIfContent(&b, tt.tag, nil, tt.chunks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want || n != len(tt.want) {
+				t.Errorf("WriteTagIfContent() = %q, %d, want %q", b.String(), n, tt.want)
+			}
+		})
+	}
+	if n, err := WriteTagIfContent(&strings.Builder{}, "div", nil, nil); n != 0 || err != nil {
+		t.Errorf("WriteTagIfContent(nil) = %d, %v", n, err)
+	}
+	a := Attributes{"id": "a", "hidden": FalseValue}
+	if got, want := RenderTagIfContent("div", a, "x"), RenderTag("div", a, "x"); got != want {
+		t.Errorf("RenderTagIfContent() = %q, want %q", got, want)
+	}
+}
+
+func ExampleCanonicalizeTag() {
+	s1, _ := CanonicalizeTag(`<DIV style='margin: 0; color: red' CLASS="b a b" id=x>`)
+	s2, _ := CanonicalizeTag(`<div id="x" class="a b" style="color:red;margin:0">`)
+	fmt.Println(s1)
+	fmt.Println(s1 == s2)
+	// Output: <div id="x" class="a b" style="color:red;margin:0">
+	// true
+}
+
+func TestCanonicalizeTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"no attributes", " <P> ", "<p>", false},
+		{"void self-closing", `<br />`, `<br>`, false},
+		{"foreign self-closing", `<circle r="1"/>`, `<circle r="1"/>`, false},
+		{"inner html kept", `<span title='a "b"'>Hi <b>there</b></span>`, `<span title="a &#34;b&#34;">Hi <b>there</b></span>`, false},
+		{"boolean", `<input type=checkbox DISABLED>`, `<input disabled type="checkbox">`, false},
+		{"quoted >", `<a title="x>y" href='/'>z</a>`, `<a href="/" title="x&gt;y">z</a>`, false},
+		{"not a tag", `text`, "", true},
+		{"closing tag", `</div>`, "", true},
+		{"unclosed", `<div class="a"`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalizeTag(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CanonicalizeTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CanonicalizeTag() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func ExampleRenderVoidTag() {
 	fmt.Println(RenderVoidTag("img", Attributes{"src": "thisFile"}))
 	// Output: <img src="thisFile">
@@ -178,7 +276,7 @@ func Test_writeTag(t *testing.T) {
 		attr      Attributes
 		innerHtml io.WriterTo
 		isVoid    bool
-		noSpace   bool
+		ws        WhitespacePolicy
 		format    bool
 	}
 	tests := []struct {
@@ -187,17 +285,19 @@ func Test_writeTag(t *testing.T) {
 		wantW   string
 		wantErr bool
 	}{
-		{"simple void tag", args{"a", nil, nil, true, false, false}, "<a>", false},
-		{"void tag with attribute", args{"a", Attributes{"b": "c"}, nil, true, false, false}, `<a b="c">`, false},
-		{"no space", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, true, false}, `<a b="c">d</a>`, false},
-		{"space", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, false, false}, `<a b="c">` + "\n" + `d` + "\n" + `</a>`, false},
-		{"format", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, false, true}, `<a b="c">` + "\n" + `  d` + "\n" + `</a>`, false},
-		{"format no space", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, true, true}, `<a b="c">d</a>`, false},
+		{"simple void tag", args{"a", nil, nil, true, WhitespaceNewline, false}, "<a>", false},
+		{"void tag with attribute", args{"a", Attributes{"b": "c"}, nil, true, WhitespaceNewline, false}, `<a b="c">`, false},
+		{"no space", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, WhitespaceNone, false}, `<a b="c">d</a>`, false},
+		{"space", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, WhitespaceNewline, false}, `<a b="c">` + "\n" + `d` + "\n" + `</a>`, false},
+		{"format", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, WhitespaceNewline, true}, `<a b="c">` + "\n" + `  d` + "\n" + `</a>`, false},
+		{"format no space", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, WhitespaceNone, true}, `<a b="c">d</a>`, false},
+		{"single space", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, WhitespaceSpace, false}, `<a b="c"> d </a>`, false},
+		{"format single space", args{"a", Attributes{"b": "c"}, strings.NewReader("d"), false, WhitespaceSpace, true}, `<a b="c"> d </a>`, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := &bytes.Buffer{}
-			gotN, err := writeTag(w, tt.args.tag, tt.args.attr, tt.args.innerHtml, tt.args.isVoid, tt.args.noSpace, tt.args.format)
+			gotN, err := writeTag(nil, w, tt.args.tag, tt.args.attr, tt.args.innerHtml, tt.args.isVoid, tt.args.ws, tt.args.format)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("writeTag() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -218,7 +318,7 @@ func Test_writeTagErr(t *testing.T) {
 		attr      Attributes
 		innerHtml io.WriterTo
 		isVoid    bool
-		noSpace   bool
+		ws        WhitespacePolicy
 		format    bool
 	}
 	tests := []struct {
@@ -226,28 +326,28 @@ func Test_writeTagErr(t *testing.T) {
 		args args
 		n    int
 	}{
-		{"void tag 0", args{"a", nil, nil, true, false, false}, 0},
-		{"void tag 1", args{"a", nil, nil, true, false, false}, 1},
-		{"void tag 2", args{"a", nil, nil, true, false, false}, 2},
-		{"void tag 3", args{"ab", nil, nil, true, false, false}, 3},
-		{"void tag attr 2", args{"a", Attributes{"b": "c"}, nil, true, false, false}, 2},
-		{"void tag attr 3", args{"a", Attributes{"b": "c"}, nil, true, false, false}, 3},
-		{"tag 3", args{"a", nil, strings.NewReader("abc"), false, false, false}, 3},
-		{"tag 4", args{"a", nil, strings.NewReader("abc"), false, false, false}, 4},
-		{"tag 5", args{"a", nil, strings.NewReader("abc"), false, false, false}, 5},
-		{"tag 5.2", args{"a", nil, strings.NewReader("b"), false, false, false}, 5},
-		{"tag 7", args{"a", nil, strings.NewReader("b"), false, false, false}, 7},
-		{"tag 8", args{"a", nil, strings.NewReader("b"), false, false, false}, 8},
-		{"tag 9", args{"a", nil, strings.NewReader("b"), false, false, false}, 9},
-		{"tag attr 3", args{"a", Attributes{"b": "c"}, nil, false, false, false}, 3},
-		{"tag attr 5", args{"a", Attributes{"b": "c"}, nil, false, false, false}, 5},
-		{"tag attr 7", args{"a", Attributes{"b": "c"}, nil, false, false, false}, 7},
-		{"tag attr formatted 5", args{"a", Attributes{"b": "c"}, nil, false, false, true}, 5},
+		{"void tag 0", args{"a", nil, nil, true, WhitespaceNewline, false}, 0},
+		{"void tag 1", args{"a", nil, nil, true, WhitespaceNewline, false}, 1},
+		{"void tag 2", args{"a", nil, nil, true, WhitespaceNewline, false}, 2},
+		{"void tag 3", args{"ab", nil, nil, true, WhitespaceNewline, false}, 3},
+		{"void tag attr 2", args{"a", Attributes{"b": "c"}, nil, true, WhitespaceNewline, false}, 2},
+		{"void tag attr 3", args{"a", Attributes{"b": "c"}, nil, true, WhitespaceNewline, false}, 3},
+		{"tag 3", args{"a", nil, strings.NewReader("abc"), false, WhitespaceNewline, false}, 3},
+		{"tag 4", args{"a", nil, strings.NewReader("abc"), false, WhitespaceNewline, false}, 4},
+		{"tag 5", args{"a", nil, strings.NewReader("abc"), false, WhitespaceNewline, false}, 5},
+		{"tag 5.2", args{"a", nil, strings.NewReader("b"), false, WhitespaceNewline, false}, 5},
+		{"tag 7", args{"a", nil, strings.NewReader("b"), false, WhitespaceNewline, false}, 7},
+		{"tag 8", args{"a", nil, strings.NewReader("b"), false, WhitespaceNewline, false}, 8},
+		{"tag 9", args{"a", nil, strings.NewReader("b"), false, WhitespaceNewline, false}, 9},
+		{"tag attr 3", args{"a", Attributes{"b": "c"}, nil, false, WhitespaceNewline, false}, 3},
+		{"tag attr 5", args{"a", Attributes{"b": "c"}, nil, false, WhitespaceNewline, false}, 5},
+		{"tag attr 7", args{"a", Attributes{"b": "c"}, nil, false, WhitespaceNewline, false}, 7},
+		{"tag attr formatted 5", args{"a", Attributes{"b": "c"}, nil, false, WhitespaceNewline, true}, 5},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := newErrBuf(tt.n)
-			gotN, err := writeTag(w, tt.args.tag, tt.args.attr, tt.args.innerHtml, tt.args.isVoid, tt.args.noSpace, tt.args.format)
+			gotN, err := writeTag(nil, w, tt.args.tag, tt.args.attr, tt.args.innerHtml, tt.args.isVoid, tt.args.ws, tt.args.format)
 			if err == nil {
 				t.Errorf("writeTagErr() want err, got no error")
 			}
@@ -273,6 +373,10 @@ func TestIndent1(t *testing.T) {
     c</textarea>`, `<textarea height="10">a
   b
     c</textarea>`},
+		{"template", "<div>\n<template><p>\na\n</p></template>\n</div>", "  <div>\n<template><p>\na\n</p></template>\n  </div>"},
+		{"nested template", "<template id=\"a\"><template>\nb</template>\nc</template>\nd", "<template id=\"a\"><template>\nb</template>\nc</template>\n  d"},
+		{"not a template", "<templates>\na", "  <templates>\n  a"},
+		{"textarea and template", "<template>\na</template>\n<textarea>\nb</textarea>", "<template>\na</template>\n<textarea>\nb</textarea>"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -335,3 +439,308 @@ func TestRenderImage(t *testing.T) {
 		t.Errorf("TestRenderImage tag not rendered")
 	}
 }
+
+func ExampleRenderTagN() {
+	s, n := RenderTagN("p", nil, "Hi")
+	fmt.Println(s)
+	fmt.Println(n)
+	// Output:
+	// <p>
+	// Hi
+	// </p>
+	// 11
+}
+
+func TestRenderN(t *testing.T) {
+	if s, n := RenderVoidTagN("br", Attributes{"id": "a"}); n != len(s) {
+		t.Errorf("RenderVoidTagN() n = %d, want %d", n, len(s))
+	}
+	if s, n := RenderTagNoSpaceN("b", Attributes{"id": "a"}, "é"); n != len(s) {
+		t.Errorf("RenderTagNoSpaceN() n = %d, want %d", n, len(s))
+	}
+}
+
+func ExampleRenderLabelWrapped() {
+	s1 := RenderLabelWrapped(nil, "Title", "<input>", LabelBefore, "div", Attributes{"class": "wrapper"})
+	s2 := RenderLabelWrapped(nil, "Title", "<input>", LabelWrapAfter, "span", nil)
+	s3 := RenderLabelWrapped(nil, "Title", "<input>", LabelAfter, "", nil)
+	fmt.Println(s1)
+	fmt.Println(s2)
+	fmt.Println(s3)
+	// Output: <label>Title</label> <div class="wrapper"><input></div>
+	// <label>
+	// <span><input></span> Title
+	// </label>
+	// <input> <label>Title</label>
+}
+
+func ExampleRenderLabelRequired() {
+	fmt.Println(RenderLabelRequired(Attributes{"for": "name"}, "Name", `<input id="name">`, LabelBefore, true))
+	fmt.Println(RenderLabelRequired(Attributes{"for": "name"}, "Name", `<input id="name">`, LabelBefore, false))
+	// Output: <label for="name">Name <span class="required" aria-hidden="true">*</span></label> <input id="name" aria-required="true">
+	// <label for="name">Name</label> <input id="name">
+}
+
+func Test_addRequiredAttribute(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`<input>`, `<input aria-required="true">`},
+		{`<input type="text" />`, `<input type="text" aria-required="true" />`},
+		{`<input type="text"/>`, `<input type="text" aria-required="true"/>`},
+		{`<select name="a"><option>1</option></select>`, `<select name="a" aria-required="true"><option>1</option></select>`},
+		{`<input required>`, `<input required>`},
+		{`<input aria-required="false">`, `<input aria-required="false">`},
+		{`<!-- c --> <textarea title="a>b"></textarea>`, `<!-- c --> <textarea title="a>b" aria-required="true"></textarea>`},
+		{`text`, `text`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := addRequiredAttribute(tt.in); got != tt.want {
+				t.Errorf("addRequiredAttribute() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteLabelRequired(t *testing.T) {
+	r := &Renderer{RequiredIndicator: "<sup>*</sup>"}
+	b := strings.Builder{}
+	n, err := r.WriteLabelRequired(&b, nil, "A & B", strings.NewReader("<input>"), LabelWrapAfter, true)
+	want := "<label>\n<input aria-required=\"true\"> A &amp; B<sup>*</sup>\n</label>"
+	if err != nil || b.String() != want || n != len(want) {
+		t.Errorf("WriteLabelRequired() = %q, %d, %v", b.String(), n, err)
+	}
+	if s := r.RenderLabelRequired(nil, "A", "", LabelBefore, true); s != "<label>A<sup>*</sup></label> " {
+		t.Errorf("RenderLabelRequired() = %q", s)
+	}
+	if s := RenderLabelRequired(nil, "A", "", LabelBefore, true); s != "<label>A"+DefaultRequiredIndicator+"</label> " {
+		t.Errorf("RenderLabelRequired() = %q", s)
+	}
+	if s := r.RenderLabelRequired(nil, "A", "", LabelBefore, false); s != "<label>A</label> " {
+		t.Errorf("RenderLabelRequired() = %q", s)
+	}
+}
+
+func ExampleRenderTemplate() {
+	fmt.Println(RenderTemplate(Attributes{"id": "row"}, "<tr>\n  <td><slot></slot></td>\n</tr>"))
+	// Output:
+	// <template id="row"><tr>
+	//   <td><slot></slot></td>
+	// </tr></template>
+}
+
+func TestRenderTemplateFormatted(t *testing.T) {
+	inner := RenderTemplate(nil, "<p>\na\n</p>")
+	got := RenderTagFormatted("div", nil, inner)
+	want := "<div>\n<template><p>\na\n</p></template>\n</div>"
+	if got != want {
+		t.Errorf("RenderTagFormatted() = %q, want %q", got, want)
+	}
+	if got = RenderTagFormatted("template", nil, "\na\n"); got != "<template>\na\n</template>" {
+		t.Errorf("RenderTagFormatted() of template = %q", got)
+	}
+}
+
+func ExampleRenderer() {
+	r := &Renderer{Hooks: []RenderHook{func(tag string, attr Attributes) Attributes {
+		if tag == "a" && strings.HasPrefix(attr.Get("href"), "http") {
+			attr.Set("rel", "noopener noreferrer")
+		}
+		return attr
+	}}}
+
+	a := Attributes{"href": "https://example.com"}
+	fmt.Println(r.RenderTagNoSpace("a", a, "Example"))
+	fmt.Println(r.RenderTagNoSpace("a", Attributes{"href": "/local"}, "Local"))
+	fmt.Println(RenderTagNoSpace("a", a, "Example"))
+	fmt.Println(a.Has("rel"))
+	// Output: <a href="https://example.com" rel="noopener noreferrer">Example</a>
+	// <a href="/local">Local</a>
+	// <a href="https://example.com">Example</a>
+	// false
+}
+
+func ExampleOpenTag() {
+	fmt.Println(OpenTag("div", Attributes{"id": "a"}) + "content" + CloseTag("div"))
+	fmt.Println(OpenTag("img", Attributes{"src": "a.png"}) + CloseTag("img"))
+	// Output: <div id="a">content</div>
+	// <img src="a.png">
+}
+
+func ExampleRenderDetails() {
+	fmt.Println(RenderDetails(nil, "More <info>", true, "<p>Details</p>", nil))
+	fmt.Println(RenderDetails(nil, "Closed", false, "", Attributes{"open": ""}))
+	// Output:
+	// <details open>
+	// <summary>More &lt;info&gt;</summary>
+	// <p>Details</p>
+	// </details>
+	// <details>
+	// <summary>Closed</summary>
+	// </details>
+}
+
+func ExampleRenderList() {
+	items := []ListItem{
+		{Text: "Salt & pepper"},
+		{Html: "<b>Bread</b>", Attributes: Attributes{"class": "bold"}},
+	}
+	fmt.Println(RenderList(false, nil, items))
+	fmt.Println(RenderList(true, Attributes{"start": "3"}, items[:1]))
+	// Output:
+	// <ul>
+	// <li>Salt &amp; pepper</li>
+	// <li class="bold"><b>Bread</b></li>
+	// </ul>
+	// <ol start="3">
+	// <li>Salt &amp; pepper</li>
+	// </ol>
+}
+
+func TestWriteList(t *testing.T) {
+	if s := RenderList(false, nil, nil); s != "<ul></ul>" {
+		t.Errorf("RenderList() with no items got %q", s)
+	}
+	b := strings.Builder{}
+	n, err := WriteList(&b, true, nil, []ListItem{{Text: "a"}})
+	if err != nil || n != b.Len() {
+		t.Errorf("WriteList() n = %d, err = %v, len = %d", n, err, b.Len())
+	}
+}
+
+func ExampleRenderForm() {
+	fmt.Println(RenderForm("/save", "POST", nil, "csrf", "abc123", `<button type="submit">Save</button>`))
+	// Output:
+	// <form action="/save" method="post">
+	// <input name="csrf" value="abc123" type="hidden">
+	// <button type="submit">Save</button>
+	// </form>
+}
+
+func TestWriteForm(t *testing.T) {
+	b := strings.Builder{}
+	if _, err := WriteForm(&b, "/", "put", nil, "", "", nil); err == nil {
+		t.Error("expected an error on an invalid method")
+	}
+	if _, err := WriteForm(&b, "/", "post", nil, "", "abc", nil); err == nil {
+		t.Error("expected an error on a token without a name")
+	}
+	if s := RenderForm("/find", "Get", nil, "csrf", "", ""); s != `<form action="/find" method="get"></form>` {
+		t.Errorf("RenderForm() without a token got %s", s)
+	}
+	if s := RenderForm("/", "post", nil, "csrf", "x", ""); !strings.Contains(s, `value="x"`) {
+		t.Errorf("RenderForm() with only a token got %s", s)
+	}
+}
+
+func ExampleRenderTagWhitespace() {
+	fmt.Println(RenderTagWhitespace("span", nil, "a", WhitespaceSpace))
+	fmt.Println(RenderTagWhitespace("span", nil, "a", WhitespaceNone))
+	// Output: <span> a </span>
+	// <span>a</span>
+}
+
+func ExampleRenderTagCollectClasses() {
+	_, classes := RenderTagCollectClasses("div", Attributes{"class": "card"},
+		`<h5 class="card-title">Title</h5><p class="card-text small">Text <!-- <b class="hidden"> --></p>`)
+	var names []string
+	for c := range classes {
+		names = append(names, c)
+	}
+	sort.Strings(names)
+	fmt.Println(names)
+	// Output: [card card-text card-title small]
+}
+
+func TestRenderTagCollectClasses(t *testing.T) {
+	html, classes := RenderTagCollectClasses("p", nil, `<script>var s = '<b class="x">'</script><br class='y'/>`)
+	if !strings.HasPrefix(html, "<p>") {
+		t.Errorf("unexpected html %s", html)
+	}
+	if len(classes) != 1 {
+		t.Errorf("expected only class y, got %v", classes)
+	}
+	if _, ok := classes["y"]; !ok {
+		t.Errorf("expected class y, got %v", classes)
+	}
+
+	_, classes = RenderTagCollectClasses("div", nil, `<span a"b=1 class="x"></span><i style="abc" CLASS='z'></i>`)
+	if _, ok := classes["x"]; !ok || len(classes) != 2 {
+		t.Errorf("expected classes x and z next to invalid attributes, got %v", classes)
+	}
+	if _, ok := classes["z"]; !ok {
+		t.Errorf("expected class z, got %v", classes)
+	}
+}
+
+func ExampleRenderButton() {
+	fmt.Println(RenderButton("button", nil, "Save & close"))
+	fmt.Println(RenderButtonHTML("submit", nil, "<i>Go</i>"))
+	// Output: <button type="button">Save &amp; close</button>
+	// <button type="submit"><i>Go</i></button>
+}
+
+func TestWriteButton(t *testing.T) {
+	b := strings.Builder{}
+	if _, err := WriteButton(&b, "", nil, nil); err == nil {
+		t.Error("expected an error on an empty type")
+	}
+	if _, err := WriteButton(&b, "Submit", nil, nil); err == nil {
+		t.Error("expected an error on an invalid type")
+	}
+	a := Attributes{"type": "submit", "class": "btn"}
+	if s := RenderButton("reset", a, ""); s != `<button class="btn" type="reset"></button>` {
+		t.Errorf("RenderButton() = %s", s)
+	}
+	if a.Get("type") != "submit" {
+		t.Error("the given attributes should not be changed")
+	}
+	b.Reset()
+	if _, err := WriteButton(&b, "button", a, strings.NewReader("<i>Go</i>")); err != nil || b.String() != `<button class="btn" type="button"><i>Go</i></button>` {
+		t.Errorf("WriteButton() = %s, %v", b.String(), err)
+	}
+}
+
+func ExampleRenderResponsiveImage() {
+	srcset := []SrcSetEntry{{URL: "a-480.jpg", Width: 480}, {URL: "a-800.jpg", Width: 800}}
+	fmt.Println(RenderResponsiveImage("a-800.jpg", "A", srcset, "(max-width: 600px) 480px, 800px", nil))
+	// Output: <img src="a-800.jpg" alt="A" sizes="(max-width: 600px) 480px, 800px" srcset="a-480.jpg 480w, a-800.jpg 800w">
+}
+
+func TestBuildSrcSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []SrcSetEntry
+		want    string
+		wantErr bool
+	}{
+		{"empty", nil, "", false},
+		{"density", []SrcSetEntry{{URL: "a.jpg"}, {URL: "a2.jpg", Density: 2}, {URL: "a15.jpg", Density: 1.5}}, "a.jpg, a2.jpg 2x, a15.jpg 1.5x", false},
+		{"mixed", []SrcSetEntry{{URL: "a.jpg", Width: 100}, {URL: "b.jpg", Density: 2}}, "", true},
+		{"mixed default", []SrcSetEntry{{URL: "a.jpg"}, {URL: "b.jpg", Width: 200}}, "", true},
+		{"widths", []SrcSetEntry{{URL: "a.jpg", Width: 100}, {URL: "b.jpg", Width: 200}}, "a.jpg 100w, b.jpg 200w", false},
+		{"both", []SrcSetEntry{{URL: "a.jpg", Width: 100, Density: 2}}, "", true},
+		{"negative", []SrcSetEntry{{URL: "a.jpg", Width: -1}}, "", true},
+		{"space", []SrcSetEntry{{URL: "a b.jpg", Width: 100}}, "", true},
+		{"comma", []SrcSetEntry{{URL: "a.jpg,", Width: 100}}, "", true},
+		{"no url", []SrcSetEntry{{Width: 100}}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := BuildSrcSet(tt.entries)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s: BuildSrcSet() = %q, %v, want %q, wantErr %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteResponsiveImage(t *testing.T) {
+	b := strings.Builder{}
+	if _, err := WriteResponsiveImage(&b, "a.jpg", "", []SrcSetEntry{{URL: "a.jpg", Width: 100}}, " ", nil); err == nil {
+		t.Error("expected an error when sizes is missing with widths")
+	}
+	s := RenderResponsiveImage("a.jpg", "", []SrcSetEntry{{URL: "a2.jpg", Density: 2}}, "", nil)
+	if !strings.Contains(s, `srcset="a2.jpg 2x"`) || strings.Contains(s, "sizes") {
+		t.Errorf("RenderResponsiveImage() = %s", s)
+	}
+}
@@ -0,0 +1,117 @@
+package html5tag
+
+import (
+	"sort"
+	"strings"
+)
+
+// globalAttributes are the attributes allowed on every html element.
+var globalAttributes = makeSet("accesskey", "autocapitalize", "autocorrect", "autofocus", "class",
+	"contenteditable", "dir", "draggable", "enterkeyhint", "hidden", "id", "inert", "inputmode", "is", "itemid",
+	"itemprop", "itemref", "itemscope", "itemtype", "lang", "nonce", "popover", "role", "slot", "spellcheck",
+	"style", "tabindex", "title", "translate", "writingsuggestions")
+
+// noAttributes is the set of element specific attributes of elements that only take global attributes.
+var noAttributes = makeSet()
+
+// tagAttributes lists the attributes, in addition to the global ones, that are allowed on each element.
+// Elements that are not listed are not checked.
+var tagAttributes = map[string]map[string]bool{
+	"a":          makeSet("href", "target", "download", "ping", "rel", "hreflang", "type", "referrerpolicy"),
+	"area":       makeSet("alt", "coords", "shape", "href", "target", "download", "ping", "rel", "referrerpolicy"),
+	"audio":      makeSet("src", "crossorigin", "preload", "autoplay", "loop", "muted", "controls"),
+	"base":       makeSet("href", "target"),
+	"blockquote": makeSet("cite"),
+	"button": makeSet("disabled", "form", "formaction", "formenctype", "formmethod", "formnovalidate",
+		"formtarget", "name", "popovertarget", "popovertargetaction", "type", "value"),
+	"canvas":   makeSet("width", "height"),
+	"col":      makeSet("span"),
+	"colgroup": makeSet("span"),
+	"data":     makeSet("value"),
+	"del":      makeSet("cite", "datetime"),
+	"details":  makeSet("open", "name"),
+	"dialog":   makeSet("open"),
+	"embed":    makeSet("src", "type", "width", "height"),
+	"fieldset": makeSet("disabled", "form", "name"),
+	"form": makeSet("accept-charset", "action", "autocomplete", "enctype", "method", "name", "novalidate",
+		"target", "rel"),
+	"iframe": makeSet("src", "srcdoc", "name", "sandbox", "allow", "allowfullscreen", "width", "height",
+		"referrerpolicy", "loading"),
+	"img": makeSet("alt", "src", "srcset", "sizes", "crossorigin", "usemap", "ismap", "width", "height",
+		"referrerpolicy", "decoding", "loading", "fetchpriority"),
+	"input": makeSet("accept", "alt", "autocomplete", "capture", "checked", "dirname", "disabled", "form",
+		"formaction", "formenctype", "formmethod", "formnovalidate", "formtarget", "height", "list", "max",
+		"maxlength", "min", "minlength", "multiple", "name", "pattern", "placeholder", "popovertarget",
+		"popovertargetaction", "readonly", "required", "size", "src", "step", "type", "value", "width"),
+	"ins":   makeSet("cite", "datetime"),
+	"label": makeSet("for"),
+	"li":    makeSet("value"),
+	"link": makeSet("href", "crossorigin", "rel", "as", "media", "hreflang", "type", "sizes", "imagesrcset",
+		"imagesizes", "referrerpolicy", "integrity", "blocking", "color", "disabled", "fetchpriority"),
+	"map":      makeSet("name"),
+	"meta":     makeSet("name", "http-equiv", "content", "charset", "media"),
+	"meter":    makeSet("value", "min", "max", "low", "high", "optimum"),
+	"object":   makeSet("data", "type", "name", "form", "width", "height"),
+	"ol":       makeSet("reversed", "start", "type"),
+	"optgroup": makeSet("disabled", "label"),
+	"option":   makeSet("disabled", "label", "selected", "value"),
+	"output":   makeSet("for", "form", "name"),
+	"progress": makeSet("value", "max"),
+	"q":        makeSet("cite"),
+	"script": makeSet("src", "type", "nomodule", "async", "defer", "crossorigin", "integrity", "referrerpolicy",
+		"blocking", "fetchpriority"),
+	"select":   makeSet("autocomplete", "disabled", "form", "multiple", "name", "required", "size"),
+	"slot":     makeSet("name"),
+	"source":   makeSet("type", "media", "src", "srcset", "sizes", "width", "height"),
+	"style":    makeSet("media", "blocking"),
+	"td":       makeSet("colspan", "rowspan", "headers"),
+	"template": makeSet("shadowrootmode", "shadowrootdelegatesfocus", "shadowrootclonable", "shadowrootserializable"),
+	"textarea": makeSet("autocomplete", "cols", "dirname", "disabled", "form", "maxlength", "minlength", "name",
+		"placeholder", "readonly", "required", "rows", "wrap"),
+	"th":    makeSet("colspan", "rowspan", "headers", "scope", "abbr"),
+	"time":  makeSet("datetime"),
+	"track": makeSet("default", "kind", "label", "src", "srclang"),
+	"video": makeSet("src", "crossorigin", "poster", "preload", "autoplay", "playsinline", "loop", "muted",
+		"controls", "width", "height"),
+
+	"abbr": noAttributes, "address": noAttributes, "article": noAttributes, "aside": noAttributes,
+	"b": noAttributes, "bdi": noAttributes, "bdo": noAttributes, "body": noAttributes, "br": noAttributes,
+	"caption": noAttributes, "cite": noAttributes, "code": noAttributes, "datalist": noAttributes,
+	"dd": noAttributes, "dfn": noAttributes, "div": noAttributes, "dl": noAttributes, "dt": noAttributes,
+	"em": noAttributes, "figcaption": noAttributes, "figure": noAttributes, "footer": noAttributes,
+	"h1": noAttributes, "h2": noAttributes, "h3": noAttributes, "h4": noAttributes, "h5": noAttributes,
+	"h6": noAttributes, "head": noAttributes, "header": noAttributes, "hgroup": noAttributes,
+	"hr": noAttributes, "html": noAttributes, "i": noAttributes, "kbd": noAttributes, "legend": noAttributes,
+	"main": noAttributes, "mark": noAttributes, "menu": noAttributes, "nav": noAttributes,
+	"noscript": noAttributes, "p": noAttributes, "picture": noAttributes, "pre": noAttributes,
+	"rp": noAttributes, "rt": noAttributes, "ruby": noAttributes, "s": noAttributes, "samp": noAttributes,
+	"search": noAttributes, "section": noAttributes, "small": noAttributes, "span": noAttributes,
+	"strong": noAttributes, "sub": noAttributes, "summary": noAttributes, "sup": noAttributes,
+	"table": noAttributes, "tbody": noAttributes, "tfoot": noAttributes, "thead": noAttributes,
+	"title": noAttributes, "tr": noAttributes, "u": noAttributes, "ul": noAttributes, "var": noAttributes,
+	"wbr": noAttributes,
+}
+
+// ValidateForTag returns the names of the attributes that are not allowed on the given tag, sorted by name,
+// like href on a div or value on a p. Global attributes, like id and class, are allowed on every tag, as are
+// data-* and aria-* attributes, and event handler attributes of known DOM events, like onclick. See IsEventAttribute.
+//
+// Tags that are not html elements, like svg and custom elements, are not checked, and nil is returned.
+// The check covers the standard attributes of each element, so attributes used by javascript libraries,
+// like hx-get, are reported unless they are written as data attributes.
+func (a Attributes) ValidateForTag(tag string) []string {
+	allowed, ok := tagAttributes[strings.ToLower(tag)]
+	if !ok {
+		return nil
+	}
+	var names []string
+	for k := range a {
+		if globalAttributes[k] || allowed[k] ||
+			strings.HasPrefix(k, "data-") || strings.HasPrefix(k, "aria-") || IsEventAttribute(k) {
+			continue
+		}
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,40 @@
+package html5tag
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func ExampleAttributes_ValidateForTag() {
+	a := Attributes{"id": "a", "href": "/b", "value": "c", "data-x": "d"}
+	fmt.Println(a.ValidateForTag("div"))
+	fmt.Println(a.ValidateForTag("li"))
+	// Output: [href value]
+	// [href]
+}
+
+func TestAttributes_ValidateForTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		attr Attributes
+		want []string
+	}{
+		{"global", "p", Attributes{"class": "a", "style": "color:red", "tabindex": "0", "role": "note"}, nil},
+		{"wildcards", "span", Attributes{"aria-label": "a", "data-id": "1", "onclick": "f()"}, nil},
+		{"value on p", "p", Attributes{"value": "1"}, []string{"value"}},
+		{"not events", "div", Attributes{"one": "1", "only": "2", "on": "3", "onclick": "f()"}, []string{"on", "one", "only"}},
+		{"input", "input", Attributes{"type": "text", "name": "a", "href": "b"}, []string{"href"}},
+		{"upper case tag", "A", Attributes{"href": "/"}, nil},
+		{"custom element", "my-widget", Attributes{"foo": "bar"}, nil},
+		{"svg", "svg", Attributes{"viewBox": "0 0 1 1"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.attr.ValidateForTag(tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateForTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package html5tag
 
 import (
 	"html"
+	"io"
+	"strings"
 )
 
 var voidTags = map[string]bool{
@@ -31,6 +33,7 @@ type TagBuilder struct {
 	attributes Attributes
 	innerHtml  string
 	isVoid     bool
+	omit       bool
 }
 
 // NewTagBuilder starts a tag build, though you can use a tag builder from its zero value too.
@@ -73,6 +76,16 @@ func (b *TagBuilder) Class(class string) *TagBuilder {
 	return b
 }
 
+// ClassMap adds the keys in m whose value is true to the class attribute, in sorted order.
+// Classes set by a prior call to Class are kept.
+func (b *TagBuilder) ClassMap(m map[string]bool) *TagBuilder {
+	if b.attributes == nil {
+		b.attributes = NewAttributes()
+	}
+	b.attributes.AddClass(ClassMap(m))
+	return b
+}
+
 // Link is a shortcut that will set the tag to "a" and the "href" to the given destination.
 // This is not the same as an actual "link" tag, which points to resources from the header.
 func (b *TagBuilder) Link(href string) *TagBuilder {
@@ -101,8 +114,19 @@ func (b *TagBuilder) InnerText(text string) *TagBuilder {
 	return b
 }
 
+// OmitIf will cause the builder to output nothing at all if cond is true. cond is evaluated when OmitIf
+// is called, so it can be called anywhere in the chain. Once a tag is omitted, a later call
+// with a false condition will not bring it back.
+func (b *TagBuilder) OmitIf(cond bool) *TagBuilder {
+	b.omit = b.omit || cond
+	return b
+}
+
 // String ends the builder and returns the html.
 func (b *TagBuilder) String() string {
+	if b.omit {
+		return ""
+	}
 	if b.tag == "" {
 		panic("You cannot output the tag builder with no tag")
 	}
@@ -111,3 +135,24 @@ func (b *TagBuilder) String() string {
 	}
 	return RenderTag(b.tag, b.attributes, b.innerHtml)
 }
+
+// WriteTo ends the builder and writes the html to w.
+func (b *TagBuilder) WriteTo(w io.Writer) (n int64, err error) {
+	if b.omit {
+		return
+	}
+	if b.tag == "" {
+		panic("You cannot output the tag builder with no tag")
+	}
+	var n2 int
+	if b.isVoid {
+		n2, err = WriteVoidTag(w, b.tag, b.attributes)
+	} else {
+		var wto io.WriterTo
+		if b.innerHtml != "" {
+			wto = strings.NewReader(b.innerHtml)
+		}
+		n2, err = WriteTag(w, b.tag, b.attributes, wto)
+	}
+	return int64(n2), err
+}
@@ -1,6 +1,9 @@
 package html5tag
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 func ExampleTagBuilder_Tag() {
 	fmt.Println(NewTagBuilder().Tag("div"))
@@ -22,6 +25,13 @@ func ExampleTagBuilder_Class() {
 	// Output: <div class="bob sam"></div>
 }
 
+func ExampleTagBuilder_ClassMap() {
+	fmt.Println(NewTagBuilder().Tag("div").Class("btn").ClassMap(map[string]bool{"primary": true, "active": true, "disabled": false}))
+	fmt.Println(NewTagBuilder().Tag("div").ClassMap(map[string]bool{"disabled": false}))
+	// Output: <div class="btn active primary"></div>
+	// <div></div>
+}
+
 func ExampleTagBuilder_Link() {
 	fmt.Println(NewTagBuilder().Link("http://example.com"))
 	// Output: <a href="http://example.com"></a>
@@ -56,3 +66,24 @@ func ExampleTagBuilder_String() {
 	// <p>A big deal</p>
 	// </div>
 }
+
+func ExampleTagBuilder_OmitIf() {
+	for _, text := range []string{"a", ""} {
+		s := NewTagBuilder().Tag("div").InnerText(text).OmitIf(text == "").String()
+		fmt.Printf("%q\n", s)
+	}
+	fmt.Printf("%q\n", NewTagBuilder().Tag("div").OmitIf(true).OmitIf(false).String())
+	// Output:
+	// "<div>\na\n</div>"
+	// ""
+	// ""
+}
+
+func ExampleTagBuilder_WriteTo() {
+	b := strings.Builder{}
+	_, _ = NewTagBuilder().Tag("img").ID("a").WriteTo(&b)
+	_, _ = NewTagBuilder().Tag("p").OmitIf(true).WriteTo(&b)
+	_, _ = NewTagBuilder().Tag("b").WriteTo(&b)
+	fmt.Println(b.String())
+	// Output: <img id="a"><b></b>
+}
@@ -0,0 +1,134 @@
+package html5tag
+
+import (
+	"errors"
+	"html"
+	"io"
+	"strings"
+)
+
+// ErrTagStackEmpty is returned by TagStack.Close when there is no open tag to close.
+var ErrTagStackEmpty = errors.New("there is no open tag to close")
+
+// TagStack writes tags imperatively, by opening and closing them, rather than by building a tree
+// of tags first. It remembers which tags are open so that Close can write the matching end tag.
+//
+//	st := NewTagStack(w)
+//	st.Open("div", Attributes{"id": "a"})
+//	st.Text("hi")
+//	st.Close()
+//	err := st.CloseAll()
+//
+// Like TagWriter, once a write fails, further writes are ignored and the error is returned from all later calls.
+type TagStack struct {
+	w      io.Writer
+	tags   []string
+	indent string
+	r      *Renderer
+	n      int64
+	err    error
+}
+
+// NewTagStack returns a TagStack that writes to w.
+func NewTagStack(w io.Writer) *TagStack {
+	return &TagStack{w: w}
+}
+
+// SetIndent turns on indenting. Each tag and piece of text will be written on its own line, indented by
+// indent repeated once for each open tag. Pass an empty string to turn off indenting.
+func (s *TagStack) SetIndent(indent string) *TagStack {
+	s.indent = indent
+	return s
+}
+
+// SetRenderer sets the Renderer whose options apply to the tags that are opened. Pass nil to turn off the options.
+func (s *TagStack) SetRenderer(r *Renderer) *TagStack {
+	s.r = r
+	return s
+}
+
+// Open writes the opening tag of tag, and pushes it on the stack so that Close will close it.
+// Void tags, like "img", are written but not pushed, since they have no end tag.
+// The options of the Renderer given to SetRenderer apply to the tag.
+func (s *TagStack) Open(tag string, attr Attributes) error {
+	attr = s.r.apply(tag, attr)
+	s.writeIndent()
+	s.write("<" + tag)
+	if attr.isRendered() {
+		s.write(" " + attr.String())
+	}
+	s.write(">")
+	if s.err == nil && !voidTags[tag] {
+		s.tags = append(s.tags, tag)
+	}
+	return s.err
+}
+
+// Text writes the given text, escaping it first.
+func (s *TagStack) Text(text string) error {
+	s.writeIndent()
+	s.write(html.EscapeString(text))
+	return s.err
+}
+
+// Html writes the given html as is.
+func (s *TagStack) Html(h string) error {
+	s.writeIndent()
+	s.write(h)
+	return s.err
+}
+
+// Close writes the end tag of the most recently opened tag and removes it from the stack.
+// Returns ErrTagStackEmpty if there are no open tags.
+func (s *TagStack) Close() error {
+	if s.err != nil {
+		return s.err
+	}
+	if len(s.tags) == 0 {
+		return ErrTagStackEmpty
+	}
+	tag := s.tags[len(s.tags)-1]
+	s.tags = s.tags[:len(s.tags)-1]
+	s.writeIndent()
+	s.write("</" + tag + ">")
+	return s.err
+}
+
+// CloseAll closes all the open tags.
+func (s *TagStack) CloseAll() error {
+	for len(s.tags) > 0 && s.err == nil {
+		s.Close()
+	}
+	return s.err
+}
+
+// Depth returns the number of open tags.
+func (s *TagStack) Depth() int {
+	return len(s.tags)
+}
+
+// N returns the total number of bytes written so far.
+func (s *TagStack) N() int64 {
+	return s.n
+}
+
+// Err returns the first error encountered while writing, or nil if there was none.
+func (s *TagStack) Err() error {
+	return s.err
+}
+
+func (s *TagStack) writeIndent() {
+	if s.indent == "" || s.n == 0 {
+		return
+	}
+	s.write("\n" + strings.Repeat(s.indent, len(s.tags)))
+}
+
+func (s *TagStack) write(str string) {
+	if s.err != nil {
+		return
+	}
+	n, err := io.WriteString(s.w, str)
+	s.n += int64(n)
+	s.err = err
+}
@@ -0,0 +1,68 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleTagStack() {
+	b := strings.Builder{}
+	st := NewTagStack(&b)
+	_ = st.Open("div", Attributes{"id": "a"})
+	_ = st.Open("p", nil)
+	_ = st.Text("Tom & Jerry")
+	_ = st.Open("br", nil)
+	_ = st.Close()
+	_ = st.Open("p", nil)
+	_ = st.CloseAll()
+	fmt.Println(b.String())
+	// Output: <div id="a"><p>Tom &amp; Jerry<br></p><p></p></div>
+}
+
+func ExampleTagStack_SetIndent() {
+	b := strings.Builder{}
+	st := NewTagStack(&b).SetIndent("  ")
+	_ = st.Open("ul", nil)
+	_ = st.Open("li", nil)
+	_ = st.Html("<b>a</b>")
+	_ = st.CloseAll()
+	fmt.Println(b.String())
+	// Output:
+	// <ul>
+	//   <li>
+	//     <b>a</b>
+	//   </li>
+	// </ul>
+}
+
+func TestTagStack(t *testing.T) {
+	b := strings.Builder{}
+	st := NewTagStack(&b)
+	if err := st.Close(); err != ErrTagStackEmpty {
+		t.Errorf("Close() on empty stack = %v", err)
+	}
+	_ = st.Open("div", nil)
+	if st.Depth() != 1 {
+		t.Errorf("Depth() = %d", st.Depth())
+	}
+	_ = st.Close()
+	if err := st.Close(); err != ErrTagStackEmpty {
+		t.Errorf("Close() after closing everything = %v", err)
+	}
+	if st.N() != int64(b.Len()) {
+		t.Errorf("N() = %d, want %d", st.N(), b.Len())
+	}
+
+	w := newErrBuf(3)
+	st = NewTagStack(w)
+	if err := st.Open("div", nil); err == nil {
+		t.Error("expected a write error")
+	}
+	if st.Depth() != 0 {
+		t.Error("tag should not be pushed after a write error")
+	}
+	if err := st.CloseAll(); err == nil || st.Err() == nil {
+		t.Error("expected the write error to stick")
+	}
+}
@@ -0,0 +1,80 @@
+package html5tag
+
+import (
+	"html"
+	"io"
+	"strings"
+)
+
+// TagWriter wraps an io.Writer so that a series of tags can be written without checking for an error after
+// each call. It keeps a running total of the bytes written, and once a write fails, all further
+// writes are ignored and the first error is remembered. Check Err at the end to see if something went wrong.
+//
+//	tw := NewTagWriter(w)
+//	tw.Tag("h1", nil, "Title")
+//	tw.VoidTag("hr", nil)
+//	tw.Text("Tom & Jerry")
+//	if err := tw.Err(); err != nil {...}
+type TagWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+// NewTagWriter returns a TagWriter that writes to w.
+func NewTagWriter(w io.Writer) *TagWriter {
+	return &TagWriter{w: w}
+}
+
+// Tag writes a standard html tag with a closing tag. innerHtml is html, and must already be escaped if needed.
+//
+// See WriteTag.
+func (t *TagWriter) Tag(tag string, attr Attributes, innerHtml string) *TagWriter {
+	if t.err != nil {
+		return t
+	}
+	var wto io.WriterTo
+	if innerHtml != "" {
+		wto = strings.NewReader(innerHtml)
+	}
+	n, err := WriteTag(t.w, tag, attr, wto)
+	t.add(n, err)
+	return t
+}
+
+// VoidTag writes a void tag.
+//
+// See WriteVoidTag.
+func (t *TagWriter) VoidTag(tag string, attr Attributes) *TagWriter {
+	if t.err != nil {
+		return t
+	}
+	n, err := WriteVoidTag(t.w, tag, attr)
+	t.add(n, err)
+	return t
+}
+
+// Text writes the given text, escaping it first.
+func (t *TagWriter) Text(text string) *TagWriter {
+	if t.err != nil {
+		return t
+	}
+	n, err := io.WriteString(t.w, html.EscapeString(text))
+	t.add(n, err)
+	return t
+}
+
+// Err returns the first error encountered while writing, or nil if there was none.
+func (t *TagWriter) Err() error {
+	return t.err
+}
+
+// N returns the total number of bytes written so far.
+func (t *TagWriter) N() int64 {
+	return t.n
+}
+
+func (t *TagWriter) add(n int, err error) {
+	t.n += int64(n)
+	t.err = err
+}
@@ -0,0 +1,39 @@
+package html5tag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleTagWriter() {
+	b := strings.Builder{}
+	tw := NewTagWriter(&b)
+	tw.Tag("h1", nil, "Title").
+		VoidTag("hr", Attributes{"id": "a"}).
+		Text("Tom & Jerry")
+	fmt.Println(b.String())
+	fmt.Println(tw.N(), tw.Err())
+	// Output:
+	// <h1>
+	// Title
+	// </h1><hr id="a">Tom &amp; Jerry
+	// 42 <nil>
+}
+
+func TestTagWriterErr(t *testing.T) {
+	w := newErrBuf(5)
+	tw := NewTagWriter(w)
+	tw.VoidTag("br", nil)
+	tw.VoidTag("hr", nil)
+	if tw.Err() == nil {
+		t.Fatal("expected an error")
+	}
+	if tw.N() != 5 {
+		t.Errorf("expected 5 bytes written, got %d", tw.N())
+	}
+	tw.Text("more")
+	if tw.N() != 5 || w.buf.String() != "<br><" {
+		t.Errorf("expected writes to stop after an error, got %q", w.buf.String())
+	}
+}
@@ -0,0 +1,83 @@
+package html5tag
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// allowedURLSchemes are the schemes that NormalizeURL accepts.
+var allowedURLSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"tel":    true,
+}
+
+var urlSchemeMatcher = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.\-]*):`)
+
+// dataImageMatcher matches data urls of raster images. SVG images are left out, since they can contain scripts.
+var dataImageMatcher = regexp.MustCompile(`^data:image/(png|gif|jpeg|jpg|webp|avif|bmp|x-icon|vnd\.microsoft\.icon)[;,]`)
+
+// NormalizeURL cleans up and checks a url that will be used as the value of an href or src attribute,
+// and is the place to sanitize links that come from users.
+//
+// Surrounding white space is removed, as are tabs and newlines anywhere in the url, since browsers ignore them.
+// The url must be relative, a fragment, use the http, https, mailto or tel schemes, or be a data url of
+// a raster image. Anything else, including javascript: and vbscript: urls, returns an error.
+func NormalizeURL(raw string) (string, error) {
+	u := strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+
+	for _, r := range u {
+		if r < 0x20 || r == 0x7f {
+			return "", fmt.Errorf("url %q contains a control character", raw)
+		}
+	}
+
+	m := urlSchemeMatcher.FindStringSubmatch(u)
+	if m == nil {
+		// relative urls and fragments
+		return u, nil
+	}
+	scheme := strings.ToLower(m[1])
+	if allowedURLSchemes[scheme] {
+		return u, nil
+	}
+	if scheme == "data" && dataImageMatcher.MatchString(strings.ToLower(u)) {
+		return u, nil
+	}
+	return "", fmt.Errorf("url scheme %q is not allowed", scheme)
+}
+
+// mimeTypeMatcher matches a mime type, like "image/png" or "image/svg+xml; charset=utf-8".
+var mimeTypeMatcher = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9!#$&^_.+-]*/[a-zA-Z0-9][a-zA-Z0-9!#$&^_.+-]*(\s*;\s*[a-zA-Z0-9!#$&^_.+-]+=[a-zA-Z0-9!#$&^_.+-]+)*$`)
+
+// DataURI returns a data url that contains data, base64 encoded, like "data:image/png;base64,iVBORw0KGgo=".
+// Use it to inline small images and other assets in a page.
+//
+// It panics if mimeType is not a valid mime type, like "image/png" or "image/svg+xml".
+func DataURI(mimeType string, data []byte) string {
+	if !mimeTypeMatcher.MatchString(mimeType) {
+		panic(fmt.Errorf("%q is not a valid mime type", mimeType))
+	}
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// SetDataURISrc sets the src attribute of attr to a data url that contains data. See DataURI.
+//
+// Returns an error, and does not change attr, if mimeType is not a valid mime type.
+// Note that the src is set directly, so unlike NormalizeURL, svg images are allowed, since the data
+// is expected to come from the application and not from users.
+func SetDataURISrc(attr Attributes, mimeType string, data []byte) error {
+	if !mimeTypeMatcher.MatchString(mimeType) {
+		return fmt.Errorf("%q is not a valid mime type", mimeType)
+	}
+	attr.Set("src", DataURI(mimeType, data))
+	return nil
+}
@@ -0,0 +1,92 @@
+package html5tag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleNormalizeURL() {
+	u, err := NormalizeURL("  https://example.com/a b\n ")
+	fmt.Printf("%q %v\n", u, err)
+	_, err = NormalizeURL("JavaScript:alert(1)")
+	fmt.Println(err)
+	// Output: "https://example.com/a b" <nil>
+	// url scheme "javascript" is not allowed
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"/path/to?q=1", "/path/to?q=1", false},
+		{"page.html", "page.html", false},
+		{"#top", "#top", false},
+		{"//cdn.example.com/a.js", "//cdn.example.com/a.js", false},
+		{"HTTP://example.com", "HTTP://example.com", false},
+		{"mailto:a@example.com", "mailto:a@example.com", false},
+		{"tel:+15555555555", "tel:+15555555555", false},
+		{"data:image/png;base64,AAAA", "data:image/png;base64,AAAA", false},
+		{"/a:b", "/a:b", false},
+		{"?x=a:b", "?x=a:b", false},
+		{"java\tscript:alert(1)", "", true},
+		{" javascript:alert(1)", "", true},
+		{"vbscript:msgbox", "", true},
+		{"data:text/html,<script>", "", true},
+		{"data:image/svg+xml,<svg>", "", true},
+		{"ftp://example.com", "", true},
+		{"a\x00b", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeURL(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeURL(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func ExampleDataURI() {
+	fmt.Println(DataURI("image/svg+xml", []byte("<svg/>")))
+	// Output: data:image/svg+xml;base64,PHN2Zy8+
+}
+
+func TestSetDataURISrc(t *testing.T) {
+	tests := []struct {
+		mimeType string
+		valid    bool
+	}{
+		{"image/png", true},
+		{"image/svg+xml", true},
+		{"text/plain; charset=utf-8", true},
+		{"image", false},
+		{"image/", false},
+		{"/png", false},
+		{"image/png,evil", false},
+		{"image/png;base64", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mimeType, func(t *testing.T) {
+			a := Attributes{}
+			err := SetDataURISrc(a, tt.mimeType, []byte{0xff, 0x00})
+			if (err == nil) != tt.valid {
+				t.Fatalf("SetDataURISrc() error = %v", err)
+			}
+			if tt.valid && a.Get("src") != "data:"+tt.mimeType+";base64,/wA=" {
+				t.Errorf("src = %s", a.Get("src"))
+			}
+			if !tt.valid && a.Has("src") {
+				t.Error("src should not be set on an error")
+			}
+		})
+	}
+	if _, err := NormalizeURL(DataURI("image/png", []byte("x"))); err != nil {
+		t.Errorf("NormalizeURL() rejected a png data uri: %v", err)
+	}
+}
@@ -1,10 +1,13 @@
 package html5tag
 
 import (
+	"hash/fnv"
 	"html"
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // TextToHtml does a variety of transformations to make standard text presentable as HTML.
@@ -16,6 +19,66 @@ func TextToHtml(in string) (out string) {
 	return
 }
 
+// EncodeEntities escapes the characters in s that have special meaning in html, the same as html.EscapeString.
+// If numeric is true, it will also replace all non-ASCII characters with numeric character references,
+// like "&#233;", which is useful for email and other places where the character encoding of the html
+// might not be preserved.
+func EncodeEntities(s string, numeric bool) string {
+	s = html.EscapeString(s)
+	if !numeric {
+		return s
+	}
+	b := strings.Builder{}
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+		} else {
+			b.WriteString("&#")
+			b.WriteString(strconv.Itoa(int(r)))
+			b.WriteByte(';')
+		}
+	}
+	return b.String()
+}
+
+// DecodeEntities replaces named and numeric character references in s with the characters they represent.
+// It is the same as html.UnescapeString.
+func DecodeEntities(s string) string {
+	return html.UnescapeString(s)
+}
+
+// JoinHTML concatenates html fragments, like the output of several calls to RenderTag, into one fragment.
+// The fragments must already be html, and are not escaped.
+func JoinHTML(fragments ...string) string {
+	return JoinHTMLWith("", fragments...)
+}
+
+// JoinHTMLWith is like JoinHTML, but puts sep between the fragments. sep is html, like "<br>" or " ".
+// Empty fragments are skipped, so that no separators are doubled up around content that was left out.
+func JoinHTMLWith(sep string, fragments ...string) string {
+	b := strings.Builder{}
+	for _, f := range fragments {
+		if f == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(f)
+	}
+	return b.String()
+}
+
+// JoinText escapes each of the texts and joins them with sep, which is also text and is escaped too,
+// returning html. Empty texts are skipped.
+func JoinText(sep string, texts ...string) string {
+	escaped := make([]string, len(texts))
+	for i, t := range texts {
+		escaped[i] = html.EscapeString(t)
+	}
+	return JoinHTMLWith(html.EscapeString(sep), escaped...)
+}
+
 const htmlValueBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ123456789-_()!"
 
 // RandomString generates a pseudo random string of the given length
@@ -30,6 +93,60 @@ func RandomString(n int) string {
 	return string(b)
 }
 
+// LinkedIDs generates a new random id that starts with prefix, and returns it with the attributes for
+// both ends of an aria-controls relationship, so that the two always match. controls contains
+// the aria-controls attribute for the element doing the controlling, like a button, and target contains the id
+// for the element being controlled, like a panel. Merge them into the attributes of each element.
+func LinkedIDs(prefix string) (id string, controls Attributes, target Attributes) {
+	id = prefix + RandomString(8)
+	controls = Attributes{"aria-controls": id}
+	target = Attributes{"id": id}
+	return
+}
+
+// HashString returns a short, deterministic hash of s that is suitable for use in css class names and html ids.
+// The same input always produces the same output, so it can be used to build stable, scoped identifiers,
+// like "btn_" + HashString(componentName).
+//
+// The result is made up of lowercase letters and digits, always starts with a letter, and is at most 14 characters
+// long. It is based on a 64-bit FNV-1a hash, so collisions are unlikely, but it is not a cryptographic hash.
+func HashString(s string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	v := h.Sum64()
+	return string(rune('a'+v%26)) + strconv.FormatUint(v/26, 36)
+}
+
+// SlugifyID converts s into an id that is safe to use in css selectors and javascript, like "user-name-primary"
+// for "User Name (primary)". The same input always produces the same output.
+//
+// The result is lowercase, made up only of the letters a-z, digits and single hyphens, and does not start or end
+// with a hyphen. If it would not start with a letter, it is prefixed with "id-", and if nothing would be left,
+// "id" is returned.
+func SlugifyID(s string) string {
+	b := strings.Builder{}
+	hyphen := false
+	for _, c := range strings.ToLower(s) {
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(c)
+		} else {
+			hyphen = true
+		}
+	}
+	id := b.String()
+	if id == "" {
+		return "id"
+	}
+	if id[0] < 'a' || id[0] > 'z' {
+		id = "id-" + id
+	}
+	return id
+}
+
 func init() {
 	rand.Seed(time.Now().UnixNano())
 }
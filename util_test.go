@@ -2,6 +2,7 @@ package html5tag
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -19,3 +20,92 @@ func ExampleTextToHtml() {
 	fmt.Println(s)
 	// Output: This is a &amp; test.<p>A paragraph<br />with a forced break.
 }
+
+func ExampleEncodeEntities() {
+	fmt.Println(EncodeEntities("Café <b> & 😀", false))
+	fmt.Println(EncodeEntities("Café <b> & 😀", true))
+	// Output: Café &lt;b&gt; &amp; 😀
+	// Caf&#233; &lt;b&gt; &amp; &#128512;
+}
+
+func ExampleDecodeEntities() {
+	fmt.Println(DecodeEntities("Caf&#233; &lt;b&gt; &amp; &eacute; &#x1F600;"))
+	// Output: Café <b> & é 😀
+}
+
+func ExampleSlugifyID() {
+	fmt.Println(SlugifyID("User Name (primary)"))
+	// Output: user-name-primary
+}
+
+func TestSlugifyID(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"abc", "abc"},
+		{"  Hello,   World!  ", "hello-world"},
+		{"a--b", "a-b"},
+		{"-a-", "a"},
+		{"123 Main St", "id-123-main-st"},
+		{"Café", "caf"},
+		{"", "id"},
+		{"!!!", "id"},
+		{"日本", "id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := SlugifyID(tt.in); got != tt.want {
+				t.Errorf("SlugifyID(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleJoinHTML() {
+	fmt.Println(JoinHTML(RenderTagNoSpace("b", nil, "Bold"), "", RenderTagNoSpace("i", nil, "Italic")))
+	fmt.Println(JoinHTMLWith(" | ", "<a>1</a>", "", "<a>2</a>"))
+	// Output: <b>Bold</b><i>Italic</i>
+	// <a>1</a> | <a>2</a>
+}
+
+func ExampleJoinText() {
+	fmt.Println(JoinText(" & ", "Tom", "", "<Jerry>"))
+	// Output: Tom &amp; &lt;Jerry&gt;
+}
+
+func TestHashString(t *testing.T) {
+	seen := map[string]string{}
+	for i := 0; i < 10000; i++ {
+		in := fmt.Sprintf("button%d", i)
+		h := HashString(in)
+		if h != HashString(in) {
+			t.Fatal("HashString is not deterministic")
+		}
+		if h[0] < 'a' || h[0] > 'z' || len(h) > 14 {
+			t.Fatalf("HashString(%q) = %q is not a valid identifier", in, h)
+		}
+		for _, c := range h {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+				t.Fatalf("HashString(%q) = %q has an invalid character", in, h)
+			}
+		}
+		if prev, ok := seen[h]; ok {
+			t.Fatalf("HashString collision between %q and %q", prev, in)
+		}
+		seen[h] = in
+	}
+}
+
+func TestLinkedIDs(t *testing.T) {
+	id, controls, target := LinkedIDs("panel-")
+	if !strings.HasPrefix(id, "panel-") || len(id) != 14 {
+		t.Errorf("unexpected id %q", id)
+	}
+	if controls.Get("aria-controls") != id || target.ID() != id {
+		t.Errorf("attributes do not match the id: %v %v", controls, target)
+	}
+	if id2, _, _ := LinkedIDs("panel-"); id2 == id {
+		t.Error("expected a different id each time")
+	}
+}